@@ -1,6 +1,7 @@
 package copper
 
 import (
+	"io"
 	"log"
 	"os"
 	"os/signal"
@@ -55,12 +56,12 @@ func (a *App) Run(fns ...Runner) {
 		err := fns[i].Run()
 		if err != nil {
 			a.Logger.Error("Failed to run", err)
-			a.Lifecycle.Stop(a.Logger)
+			a.stop()
 			os.Exit(1)
 		}
 	}
 
-	a.Lifecycle.Stop(a.Logger)
+	a.stop()
 }
 
 // Start runs the provided fns and then waits on the OS's INT and TERM signals from the
@@ -83,5 +84,20 @@ func (a *App) Start(fns ...Runner) {
 
 	<-osInt
 
-	a.Lifecycle.Stop(a.Logger)
+	a.stop()
+}
+
+func (a *App) stop() {
+	err := a.Lifecycle.Stop(a.Logger)
+	if err != nil {
+		a.Logger.Error("Failed to stop cleanly", err)
+	}
+
+	// Close the logger last so that logs written while stopping are not lost
+	if closer, ok := a.Logger.(io.Closer); ok {
+		err = closer.Close()
+		if err != nil {
+			log.Println(err)
+		}
+	}
 }
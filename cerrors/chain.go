@@ -0,0 +1,105 @@
+package cerrors
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Chain is a walkable representation of an error along with its causes. The first link is the outermost error.
+	Chain []Link
+
+	// Link is a single error in a Chain. Each link only holds the context (ex. tags) that was added at its level.
+	Link struct {
+		Message string                 `json:"message"`
+		Tags    map[string]interface{} `json:"tags,omitempty"`
+		Kind    Kind                   `json:"kind,omitempty"`
+		Code    string                 `json:"code,omitempty"`
+		Stack   []StackFrame           `json:"stack,omitempty"`
+
+		// Joined holds the chains of each of the errors combined using Join
+		Joined []Chain `json:"joined,omitempty"`
+	}
+)
+
+// ChainOf walks the given error and returns a link for each of the errors in its chain. Errors that only annotate
+// their cause (ex. created with WithKind) are folded into the next link. Errors created with Join end the chain with
+// a link that holds the chain of each joined error.
+func ChainOf(err error) Chain {
+	var (
+		chain   = make(Chain, 0)
+		pending Link
+	)
+
+	for err != nil {
+		var next error
+
+		link := pending
+		pending = Link{}
+
+		switch e := err.(type) { //nolint:errorlint
+		case Error:
+			if e.Message == "" && e.Cause != nil {
+				pending = mergeLinks(link, Link{Tags: e.Tags, Kind: e.Kind, Code: e.Code, Stack: Frames(e.stack)})
+				err = e.Cause
+
+				continue
+			}
+
+			link = mergeLinks(link, Link{
+				Message: e.Message,
+				Tags:    e.Tags,
+				Kind:    e.Kind,
+				Code:    e.Code,
+				Stack:   Frames(e.stack),
+			})
+			next = e.Cause
+		case interface{ Unwrap() []error }:
+			errs := e.Unwrap()
+
+			link.Message = strconv.Itoa(len(errs)) + " errors occurred"
+			link.Joined = make([]Chain, len(errs))
+
+			for i := range errs {
+				link.Joined[i] = ChainOf(errs[i])
+			}
+		default:
+			next = errors.Unwrap(err)
+
+			link.Message = err.Error()
+			if next != nil {
+				link.Message = strings.TrimSuffix(link.Message, ": "+next.Error())
+			}
+		}
+
+		chain = append(chain, link)
+		err = next
+	}
+
+	return chain
+}
+
+// mergeLinks combines the context from both links. Values in l2 are preferred over l1 except for the kind and code
+// where the outer value, i.e. l1, wins.
+func mergeLinks(l1, l2 Link) Link {
+	merged := l2
+
+	if len(l1.Tags) > 0 {
+		merged.Tags = mergeTags(l1.Tags, l2.Tags)
+	}
+
+	if l1.Kind != KindUnknown {
+		merged.Kind = l1.Kind
+	}
+
+	if l1.Code != "" {
+		merged.Code = l1.Code
+	}
+
+	if len(merged.Stack) == 0 {
+		merged.Stack = l1.Stack
+	}
+
+	return merged
+}
@@ -0,0 +1,85 @@
+package cerrors_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gocopper/copper/cerrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainOf(t *testing.T) {
+	t.Parallel()
+
+	err := cerrors.WithKind(errors.New("no rows"), cerrors.KindNotFound) //nolint:goerr113
+	err = cerrors.New(err, "failed to get user", map[string]interface{}{"id": 1})
+	err = fmt.Errorf("handler failed: %w", err)
+
+	chain := cerrors.ChainOf(err)
+
+	assert.Len(t, chain, 3)
+
+	assert.Equal(t, "handler failed", chain[0].Message)
+
+	assert.Equal(t, "failed to get user", chain[1].Message)
+	assert.Equal(t, map[string]interface{}{"id": 1}, chain[1].Tags)
+	assert.Equal(t, cerrors.KindUnknown, chain[1].Kind)
+	assert.NotEmpty(t, chain[1].Stack)
+	assert.Equal(t, "github.com/gocopper/copper/cerrors_test.TestChainOf", chain[1].Stack[0].Function)
+
+	assert.Equal(t, "no rows", chain[2].Message)
+	assert.Equal(t, cerrors.KindNotFound, chain[2].Kind)
+	assert.Empty(t, chain[2].Stack)
+}
+
+func TestChainOf_Join(t *testing.T) {
+	t.Parallel()
+
+	err := cerrors.Join(
+		cerrors.New(nil, "test-err-1", map[string]interface{}{"key1": "val1"}),
+		cerrors.New(errors.New("cause-err"), "test-err-2", nil), //nolint:goerr113
+	)
+
+	chain := cerrors.ChainOf(err)
+
+	assert.Len(t, chain, 1)
+	assert.Equal(t, "2 errors occurred", chain[0].Message)
+	assert.Len(t, chain[0].Joined, 2)
+
+	assert.Equal(t, "test-err-1", chain[0].Joined[0][0].Message)
+	assert.Equal(t, map[string]interface{}{"key1": "val1"}, chain[0].Joined[0][0].Tags)
+
+	assert.Len(t, chain[0].Joined[1], 2)
+	assert.Equal(t, "cause-err", chain[0].Joined[1][1].Message)
+}
+
+func TestChainOf_Nil(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, cerrors.ChainOf(nil))
+}
+
+func TestError_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	err := cerrors.New(cerrors.New(nil, "test-err-2", map[string]interface{}{
+		"key2": "val2",
+	}), "test-err-1", map[string]interface{}{
+		"key1": "val1",
+	})
+
+	out, jsonErr := json.Marshal(err)
+	assert.NoError(t, jsonErr)
+
+	var chain []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(out, &chain))
+
+	assert.Len(t, chain, 2)
+	assert.Equal(t, "test-err-1", chain[0]["message"])
+	assert.Equal(t, map[string]interface{}{"key1": "val1"}, chain[0]["tags"])
+	assert.Equal(t, "test-err-2", chain[1]["message"])
+	assert.Equal(t, map[string]interface{}{"key2": "val2"}, chain[1]["tags"])
+	assert.NotEmpty(t, chain[1]["stack"])
+}
@@ -2,6 +2,7 @@
 package cerrors
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
@@ -10,20 +11,54 @@ import (
 )
 
 // Error can wrap an error with additional context such as structured tags.
+// Message and Tags are meant for logs and may contain internal details while
+// Code and PublicMessage are safe to show to end-users.
 type Error struct {
-	Message string
-	Tags    map[string]interface{}
-	Cause   error
+	Message       string
+	Tags          map[string]interface{}
+	Kind          Kind
+	Code          string
+	PublicMessage string
+	Cause         error
+
+	stack []uintptr
 }
 
 // New creates an error by (optionally) wrapping an existing error and
-// annotating the error with structured tags.
+// annotating the error with structured tags. The stack of the caller is
+// captured unless the cause already has one, so that it is only captured
+// once where the error originated.
 func New(cause error, msg string, tags map[string]interface{}) error {
+	var stack []uintptr
+	if !hasStack(cause) {
+		stack = Callers(1)
+	}
+
 	return Error{
 		Message: msg,
 		Tags:    tags,
 		Cause:   cause,
+		stack:   stack,
+	}
+}
+
+// hasStack returns true if err, or any of its causes, is an Error with a stack.
+func hasStack(err error) bool {
+	for err != nil {
+		cerr, ok := err.(Error) //nolint:errorlint
+		if !ok {
+			err = errors.Unwrap(err)
+			continue
+		}
+
+		if cerr.stack != nil {
+			return true
+		}
+
+		err = cerr.Cause
 	}
+
+	return false
 }
 
 // WithTags annotates an existing error with structured tags.
@@ -37,11 +72,20 @@ func WithTags(err error, tags map[string]interface{}) error {
 		}
 	}
 
-	return Error{
-		Message: cerr.Message,
-		Tags:    tags,
-		Cause:   cerr.Cause,
+	cerr.Tags = tags
+
+	return cerr
+}
+
+// annotate returns err as an Error so that it can be annotated with a kind or a public message. If err is not an
+// Error, it is wrapped without a message so that Error() and errors.Is continue to work on the original error.
+func annotate(err error) Error {
+	cerr, ok := err.(Error) //nolint:errorlint
+	if !ok {
+		return Error{Cause: err}
 	}
+
+	return cerr
 }
 
 // Unwrap returns the underlying cause of an error (if any).
@@ -49,10 +93,20 @@ func (e Error) Unwrap() error {
 	return e.Cause
 }
 
+// MarshalJSON encodes the error chain as returned by ChainOf.
+func (e Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ChainOf(e))
+}
+
 // Error returns a human-friendly string that contains the
 // entire error chain along with all of the tags on each
 // error.
 func (e Error) Error() string {
+	// Errors without a message (ex. created by WithKind) only annotate their cause
+	if e.Message == "" && len(e.Tags) == 0 && e.Cause != nil {
+		return e.Cause.Error()
+	}
+
 	var (
 		err  strings.Builder
 		tags []string
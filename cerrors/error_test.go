@@ -2,6 +2,7 @@ package cerrors_test
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/gocopper/copper/cerrors"
@@ -21,6 +22,21 @@ func TestNew(t *testing.T) {
 	assert.Nil(t, cErr.Tags)
 }
 
+func TestNew_StackAtRoot(t *testing.T) {
+	t.Parallel()
+
+	err := cerrors.New(nil, "test-root", nil)
+	err = fmt.Errorf("test-wrap: %w", err)
+	err = cerrors.New(err, "test-outer", nil)
+
+	chain := cerrors.ChainOf(err)
+
+	assert.Len(t, chain, 3)
+	assert.Empty(t, chain[0].Stack)
+	assert.NotEmpty(t, chain[2].Stack)
+	assert.Equal(t, "github.com/gocopper/copper/cerrors_test.TestNew_StackAtRoot", chain[2].Stack[0].Function)
+}
+
 func TestWithTags_StdErr(t *testing.T) {
 	t.Parallel()
 
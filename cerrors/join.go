@@ -0,0 +1,51 @@
+package cerrors
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Join combines the given errors into a single Error. Each of the errors keeps its own message, tags, and cause so
+// that the combined error can be inspected using errors.Is, errors.As, and Tags. Nil errors are discarded and if
+// all of the errors are nil, Join returns nil.
+func Join(errs ...error) error {
+	nonNilErrs := make([]error, 0, len(errs))
+	for _, err := range errs {
+		if err != nil {
+			nonNilErrs = append(nonNilErrs, err)
+		}
+	}
+
+	if len(nonNilErrs) == 0 {
+		return nil
+	}
+
+	return Error{
+		Cause: &joinError{errs: nonNilErrs},
+	}
+}
+
+type joinError struct {
+	errs []error
+}
+
+// Unwrap returns the joined errors. It allows errors.Is and errors.As to inspect each of them.
+func (e *joinError) Unwrap() []error {
+	return e.errs
+}
+
+// Error returns a human-friendly string with each of the joined errors on its own line. Multi-line errors (ex. errors
+// with a cause) are indented so that they are grouped together.
+func (e *joinError) Error() string {
+	var out strings.Builder
+
+	out.WriteString(strconv.Itoa(len(e.errs)))
+	out.WriteString(" errors occurred:")
+
+	for _, err := range e.errs {
+		out.WriteString("\n* ")
+		out.WriteString(strings.ReplaceAll(err.Error(), "\n", "\n  "))
+	}
+
+	return out.String()
+}
@@ -0,0 +1,101 @@
+package cerrors_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gocopper/copper/cerrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoin(t *testing.T) {
+	t.Parallel()
+
+	var (
+		err1 = cerrors.New(nil, "test-err-1", map[string]interface{}{"key1": "val1"})
+		err2 = errors.New("test-err-2") //nolint:goerr113
+	)
+
+	err := cerrors.Join(err1, nil, err2)
+
+	_, ok := err.(cerrors.Error) //nolint:errorlint
+
+	assert.True(t, ok)
+	assert.ErrorIs(t, err, err2)
+
+	var cerr cerrors.Error
+	assert.True(t, errors.As(err, &cerr))
+}
+
+func TestJoin_Nil(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, cerrors.Join())
+	assert.Nil(t, cerrors.Join(nil, nil))
+}
+
+func TestJoin_Error(t *testing.T) {
+	t.Parallel()
+
+	err := cerrors.Join(
+		cerrors.New(errors.New("cause-err"), "test-err-1", map[string]interface{}{"key": "val"}), //nolint:goerr113
+		cerrors.New(nil, "test-err-2", nil),
+	)
+
+	expectedErr := `2 errors occurred:
+* test-err-1 where key=val because
+  > cause-err
+* test-err-2`
+
+	assert.Equal(t, expectedErr, err.Error())
+}
+
+func TestJoin_Wrapped(t *testing.T) {
+	t.Parallel()
+
+	err := cerrors.New(cerrors.Join(
+		cerrors.New(nil, "test-err-1", nil),
+		cerrors.New(nil, "test-err-2", nil),
+	), "failed to stop", nil)
+
+	expectedErr := `failed to stop because
+> 2 errors occurred:
+* test-err-1
+* test-err-2`
+
+	assert.Equal(t, expectedErr, err.Error())
+}
+
+func TestJoin_Tags(t *testing.T) {
+	t.Parallel()
+
+	err := cerrors.New(cerrors.Join(
+		cerrors.New(nil, "test-err-1", map[string]interface{}{"key1": "val1"}),
+		cerrors.New(cerrors.New(nil, "test-err-2", map[string]interface{}{"key2": "val2"}), "test-err-3", nil),
+		errors.New("test-err-4"), //nolint:goerr113
+	), "test-err", map[string]interface{}{"key0": "val0"})
+
+	assert.Equal(t, map[string]interface{}{
+		"key0": "val0",
+		"key1": "val1",
+		"key2": "val2",
+	}, cerrors.Tags(err))
+}
+
+func TestJoin_WithoutTags(t *testing.T) {
+	t.Parallel()
+
+	var (
+		err2 = errors.New("test-err-2") //nolint:goerr113
+		err  = cerrors.Join(
+			cerrors.New(nil, "test-err-1", map[string]interface{}{"key1": "val1"}),
+			err2,
+		)
+	)
+
+	out := cerrors.WithoutTags(err)
+
+	assert.NotContains(t, out.Error(), "key1")
+	assert.Contains(t, out.Error(), "test-err-1")
+	assert.ErrorIs(t, out, err2)
+}
@@ -0,0 +1,58 @@
+package cerrors
+
+// Kind classifies an error (ex. not found, invalid input) so that callers far away from where the error was created
+// can decide how to handle it. A kind survives wrapping with New, WithTags, and fmt.Errorf's %w.
+type Kind string
+
+// Kinds supported by WithKind and KindOf. KindUnknown is the zero value and is used for errors that have not been
+// classified.
+const (
+	KindUnknown      = Kind("")
+	KindNotFound     = Kind("not_found")
+	KindInvalid      = Kind("invalid")
+	KindConflict     = Kind("conflict")
+	KindUnauthorized = Kind("unauthorized")
+	KindForbidden    = Kind("forbidden")
+	KindUnavailable  = Kind("unavailable")
+)
+
+// WithKind classifies err with the given kind. If err is not an Error, it is wrapped so that errors.Is and errors.As
+// continue to work on the original error.
+func WithKind(err error, kind Kind) error {
+	if err == nil {
+		return nil
+	}
+
+	cerr := annotate(err)
+	cerr.Kind = kind
+
+	return cerr
+}
+
+// KindOf returns the kind of the given error. If multiple errors in the chain have a kind, the outermost one is
+// returned. The errors joined with Join or errors.Join are checked in order. If none of them have a kind,
+// KindUnknown is returned.
+func KindOf(err error) Kind {
+	switch e := err.(type) { //nolint:errorlint
+	case nil:
+		return KindUnknown
+	case Error:
+		if e.Kind != KindUnknown {
+			return e.Kind
+		}
+
+		return KindOf(e.Cause)
+	case interface{ Unwrap() []error }:
+		for _, child := range e.Unwrap() {
+			if kind := KindOf(child); kind != KindUnknown {
+				return kind
+			}
+		}
+
+		return KindUnknown
+	case interface{ Unwrap() error }:
+		return KindOf(e.Unwrap())
+	default:
+		return KindUnknown
+	}
+}
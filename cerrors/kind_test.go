@@ -0,0 +1,77 @@
+package cerrors_test
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gocopper/copper/cerrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithKind(t *testing.T) {
+	t.Parallel()
+
+	err := cerrors.WithKind(cerrors.New(nil, "test-err", nil), cerrors.KindNotFound)
+
+	assert.Equal(t, cerrors.KindNotFound, cerrors.KindOf(err))
+	assert.Equal(t, "test-err", err.Error())
+}
+
+func TestWithKind_StdErr(t *testing.T) {
+	t.Parallel()
+
+	err := cerrors.WithKind(sql.ErrNoRows, cerrors.KindNotFound)
+
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+	assert.Equal(t, cerrors.KindNotFound, cerrors.KindOf(err))
+	assert.Equal(t, sql.ErrNoRows.Error(), err.Error())
+}
+
+func TestWithKind_Nil(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, cerrors.WithKind(nil, cerrors.KindInvalid))
+}
+
+func TestKindOf_Wrapped(t *testing.T) {
+	t.Parallel()
+
+	err := cerrors.WithKind(errors.New("test-err"), cerrors.KindConflict) //nolint:goerr113
+	err = cerrors.New(err, "failed to save", nil)
+	err = cerrors.WithTags(err, map[string]interface{}{"key": "val"})
+	err = fmt.Errorf("handler failed: %w", err)
+
+	assert.Equal(t, cerrors.KindConflict, cerrors.KindOf(err))
+}
+
+func TestKindOf_Outermost(t *testing.T) {
+	t.Parallel()
+
+	err := cerrors.WithKind(errors.New("test-err"), cerrors.KindNotFound) //nolint:goerr113
+	err = cerrors.WithKind(cerrors.New(err, "failed to authorize", nil), cerrors.KindForbidden)
+
+	assert.Equal(t, cerrors.KindForbidden, cerrors.KindOf(err))
+}
+
+func TestKindOf_Unknown(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, cerrors.KindUnknown, cerrors.KindOf(errors.New("test-err"))) //nolint:goerr113
+	assert.Equal(t, cerrors.KindUnknown, cerrors.KindOf(nil))
+}
+
+func TestKindOf_Joined(t *testing.T) {
+	t.Parallel()
+
+	notFound := cerrors.WithKind(errors.New("test-err"), cerrors.KindNotFound) //nolint:goerr113
+
+	err := cerrors.Join(errors.New("other-err"), notFound) //nolint:goerr113
+	assert.Equal(t, cerrors.KindNotFound, cerrors.KindOf(err))
+
+	err = fmt.Errorf("handler failed: %w", errors.Join(errors.New("other-err"), notFound)) //nolint:goerr113
+	assert.Equal(t, cerrors.KindNotFound, cerrors.KindOf(err))
+
+	assert.Equal(t, cerrors.KindUnknown, cerrors.KindOf(cerrors.Join(errors.New("other-err")))) //nolint:goerr113
+}
@@ -0,0 +1,43 @@
+package cerrors
+
+// WithPublic annotates err with a code and a message that are safe to show to end-users (ex. in an HTTP response).
+// Unlike Error(), which includes the entire cause chain along with tags, the public message is never derived from the
+// wrapped errors.
+func WithPublic(err error, code, msg string) error {
+	if err == nil {
+		return nil
+	}
+
+	cerr := annotate(err)
+	cerr.Code = code
+	cerr.PublicMessage = msg
+
+	return cerr
+}
+
+// Public returns the outermost user-safe code and message found in the error chain. The errors joined with Join or
+// errors.Join are checked in order. If none of the errors in the chain have a public message or code, ok is false.
+func Public(err error) (code, msg string, ok bool) {
+	switch e := err.(type) { //nolint:errorlint
+	case nil:
+		return "", "", false
+	case Error:
+		if e.Code != "" || e.PublicMessage != "" {
+			return e.Code, e.PublicMessage, true
+		}
+
+		return Public(e.Cause)
+	case interface{ Unwrap() []error }:
+		for _, child := range e.Unwrap() {
+			if code, msg, ok = Public(child); ok {
+				return code, msg, true
+			}
+		}
+
+		return "", "", false
+	case interface{ Unwrap() error }:
+		return Public(e.Unwrap())
+	default:
+		return "", "", false
+	}
+}
@@ -0,0 +1,63 @@
+package cerrors_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gocopper/copper/cerrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithPublic(t *testing.T) {
+	t.Parallel()
+
+	err := cerrors.WithPublic(cerrors.New(errors.New("pq: duplicate key"), "failed to insert user", map[string]interface{}{ //nolint:goerr113
+		"email": "test@example.com",
+	}), "email_taken", "This email is already registered")
+
+	code, msg, ok := cerrors.Public(fmt.Errorf("handler failed: %w", err))
+
+	assert.True(t, ok)
+	assert.Equal(t, "email_taken", code)
+	assert.Equal(t, "This email is already registered", msg)
+	assert.Contains(t, err.Error(), "pq: duplicate key")
+}
+
+func TestWithPublic_StdErr(t *testing.T) {
+	t.Parallel()
+
+	cause := errors.New("test-err") //nolint:goerr113
+	err := cerrors.WithPublic(cause, "", "Something went wrong")
+
+	_, msg, ok := cerrors.Public(err)
+
+	assert.True(t, ok)
+	assert.Equal(t, "Something went wrong", msg)
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, "test-err", err.Error())
+}
+
+func TestPublic_None(t *testing.T) {
+	t.Parallel()
+
+	_, _, ok := cerrors.Public(cerrors.New(nil, "test-err", nil))
+
+	assert.False(t, ok)
+}
+
+func TestPublic_Joined(t *testing.T) {
+	t.Parallel()
+
+	public := cerrors.WithPublic(errors.New("test-err"), "not_found", "Item not found") //nolint:goerr113
+
+	code, msg, ok := cerrors.Public(cerrors.Join(errors.New("other-err"), public)) //nolint:goerr113
+	assert.True(t, ok)
+	assert.Equal(t, "not_found", code)
+	assert.Equal(t, "Item not found", msg)
+
+	code, msg, ok = cerrors.Public(fmt.Errorf("failed: %w", errors.Join(public))) //nolint:goerr113
+	assert.True(t, ok)
+	assert.Equal(t, "not_found", code)
+	assert.Equal(t, "Item not found", msg)
+}
@@ -0,0 +1,73 @@
+package cerrors
+
+import (
+	"runtime"
+	"strings"
+)
+
+// maxStackDepth is the maximum number of frames captured by Callers.
+const maxStackDepth = 32
+
+// StackFrame is a single function call in the stack trace captured when an error was created.
+type StackFrame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// WithStack annotates err with the stack trace of its caller. It is useful for errors that are not created with New
+// (ex. values recovered from a panic) so that their origin is available in ChainOf.
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	cerr := annotate(err)
+	cerr.stack = Callers(1)
+
+	return cerr
+}
+
+// Callers captures the program counters of the stack of its caller, skipping the given number of frames (ex. 1 to
+// start from the caller's caller). The program counters are resolved into frames only when needed using Frames.
+func Callers(skip int) []uintptr {
+	const callersSkip = 2 // runtime.Callers and Callers
+
+	var pcs [maxStackDepth]uintptr
+
+	n := runtime.Callers(skip+callersSkip, pcs[:])
+
+	// Copy the program counters so that the array does not escape and only the captured frames are allocated
+	return append(make([]uintptr, 0, n), pcs[:n]...)
+}
+
+// Frames resolves the given program counters, captured with Callers, into stack frames. Frames from the Go runtime
+// are omitted.
+func Frames(pcs []uintptr) []StackFrame {
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	var (
+		frames    = runtime.CallersFrames(pcs)
+		stackFrms = make([]StackFrame, 0, len(pcs))
+	)
+
+	for {
+		frame, more := frames.Next()
+
+		if !strings.HasPrefix(frame.Function, "runtime.") {
+			stackFrms = append(stackFrms, StackFrame{
+				Function: frame.Function,
+				File:     frame.File,
+				Line:     frame.Line,
+			})
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	return stackFrms
+}
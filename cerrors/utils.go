@@ -1,28 +1,166 @@
 package cerrors
 
-import "errors"
+import (
+	"strings"
+)
 
+// Tags returns all of the tags in the error chain. If the same tag is set at multiple levels, the value from the
+// innermost error is used.
 func Tags(err error) map[string]interface{} {
-	var cerr Error
+	switch e := err.(type) { //nolint:errorlint
+	case nil:
+		return nil
+	case Error:
+		return mergeTags(e.Tags, Tags(e.Cause))
+	case interface{ Unwrap() []error }:
+		var tags map[string]interface{}
+
+		for _, child := range e.Unwrap() {
+			if childTags := Tags(child); childTags != nil {
+				tags = mergeTags(tags, childTags)
+			}
+		}
 
-	if !errors.As(err, &cerr) {
+		return tags
+	case interface{ Unwrap() error }:
+		return Tags(e.Unwrap())
+	default:
 		return nil
 	}
-
-	return mergeTags(cerr.Tags, Tags(cerr.Cause))
 }
 
+// WithoutTags returns the error chain with tags removed from each of the errors.
 func WithoutTags(err error) error {
-	var cerr Error
+	return MapTags(err, func(map[string]interface{}) map[string]interface{} {
+		return nil
+	})
+}
+
+// MapTags returns the error chain with the tags of each error replaced by the result of fn. Errors in the chain that
+// are not an Error are converted into one so that the errors they wrap can be mapped.
+func MapTags(err error, fn func(tags map[string]interface{}) map[string]interface{}) error {
+	mapped, _ := chainMapper{tags: fn}.mapErr(err)
+	return mapped
+}
+
+// MapMessages returns the error chain with the message of each error replaced by the result of fn. Errors in the
+// chain that are not an Error are converted into one if their message changes. The rest of the chain, including the
+// kinds, codes, and stacks of the errors, is kept as-is.
+func MapMessages(err error, fn func(msg string) string) error {
+	mapped, _ := chainMapper{msg: fn}.mapErr(err)
+	return mapped
+}
+
+// chainMapper implements MapTags and MapMessages. A nil func leaves the tags or messages unchanged.
+type chainMapper struct {
+	tags func(tags map[string]interface{}) map[string]interface{}
+	msg  func(msg string) string
+}
+
+// mapErr maps the error chain. It also returns false if nothing was mapped so that the original error can be kept
+// as-is.
+func (m chainMapper) mapErr(err error) (error, bool) {
+	switch e := err.(type) { //nolint:errorlint
+	case nil:
+		return nil, false
+	case Error:
+		if m.tags != nil {
+			e.Tags = m.tags(e.Tags)
+		}
+
+		e.Message = m.message(e.Message)
+		e.Cause, _ = m.mapErr(e.Cause)
+
+		return e, true
+	case *joinError:
+		errs := make([]error, len(e.errs))
+		for i := range e.errs {
+			errs[i], _ = m.mapErr(e.errs[i])
+		}
+
+		return &joinError{errs: errs}, true
+	case interface{ Unwrap() []error }:
+		// Errors that wrap multiple errors (ex. errors.Join or fmt.Errorf with multiple %w) are converted into an Error
+		// with the text around the wrapped errors as its message and the mapped errors joined as its cause.
+		var (
+			children = e.Unwrap()
+			errs     = make([]error, len(children))
+			msg      = err.Error()
+			mapped   bool
+		)
+
+		for i := range children {
+			var ok bool
 
-	if !errors.As(err, &cerr) {
-		return err
+			errs[i], ok = m.mapErr(children[i])
+			mapped = mapped || ok
+			msg = strings.Replace(msg, children[i].Error(), "", 1)
+		}
+
+		msg = trimWrapSeparators(msg)
+
+		mappedMsg := m.message(msg)
+		if !mapped && mappedMsg == msg {
+			return err, false
+		}
+
+		if mappedMsg == "" {
+			return &joinError{errs: errs}, true
+		}
+
+		return Error{
+			Message: mappedMsg,
+			Cause:   &joinError{errs: errs},
+		}, true
+	case interface{ Unwrap() error }:
+		if e.Unwrap() == nil {
+			return m.mapLeaf(err)
+		}
+
+		var (
+			cause           = e.Unwrap()
+			mappedCause, ok = m.mapErr(cause)
+			msg             = trimWrapSeparators(strings.Replace(err.Error(), cause.Error(), "", 1))
+			mappedMsg       = m.message(msg)
+		)
+
+		if !ok && mappedMsg == msg {
+			return err, false
+		}
+
+		return Error{
+			Message: mappedMsg,
+			Cause:   mappedCause,
+		}, true
+	default:
+		return m.mapLeaf(err)
+	}
+}
+
+// mapLeaf maps the message of an error that does not wrap other errors.
+func (m chainMapper) mapLeaf(err error) (error, bool) {
+	msg := err.Error()
+
+	mappedMsg := m.message(msg)
+	if mappedMsg == msg {
+		return err, false
+	}
+
+	return Error{Message: mappedMsg}, true
+}
+
+func (m chainMapper) message(msg string) string {
+	if m.msg == nil {
+		return msg
 	}
 
-	cerr.Tags = nil
-	cerr.Cause = WithoutTags(cerr.Cause)
+	return m.msg(msg)
+}
 
-	return cerr
+// trimWrapSeparators removes the separators (ex. ": ") that were left at the end of a message once the text of the
+// wrapped errors is removed from it.
+func trimWrapSeparators(msg string) string {
+	return strings.TrimRight(msg, ":;, \n")
 }
 
 func mergeTags(t1, t2 map[string]interface{}) map[string]interface{} {
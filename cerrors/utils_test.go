@@ -1,9 +1,11 @@
 package cerrors_test
 
 import (
+	"errors"
 	"fmt"
 	"github.com/gocopper/copper/cerrors"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 )
 
@@ -18,3 +20,93 @@ func TestWithoutTags(t *testing.T) {
 
 	assert.NotContains(t, out.Error(), "tag")
 }
+
+func TestMapTags(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("test-error-0: %w", cerrors.New(cerrors.Join(
+		cerrors.New(nil, "test-error-2", map[string]interface{}{"tag": "val"}),
+		errors.New("test-error-3"), //nolint:goerr113
+	), "test-error-1", map[string]interface{}{
+		"tag": "val",
+	}))
+
+	out := cerrors.MapTags(err, func(tags map[string]interface{}) map[string]interface{} {
+		if tags == nil {
+			return nil
+		}
+
+		return map[string]interface{}{"tag": "mapped"}
+	})
+
+	assert.Equal(t, `test-error-0 because
+> test-error-1 where tag=mapped because
+> 2 errors occurred:
+* test-error-2 where tag=mapped
+* test-error-3`, out.Error())
+}
+
+func TestMapTags_NoCErrors(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("test-error-0: %w", errors.New("test-error-1")) //nolint:goerr113
+
+	out := cerrors.MapTags(err, func(tags map[string]interface{}) map[string]interface{} {
+		return nil
+	})
+
+	assert.Equal(t, err, out)
+}
+
+func TestMapTags_MultiUnwrap(t *testing.T) {
+	t.Parallel()
+
+	var (
+		err1 = cerrors.New(nil, "test-error-1", map[string]interface{}{"password": "secret"})
+		err2 = errors.New("test-error-2") //nolint:goerr113
+		fn   = func(tags map[string]interface{}) map[string]interface{} {
+			if tags == nil {
+				return nil
+			}
+
+			return map[string]interface{}{"password": "redacted"}
+		}
+	)
+
+	joined := cerrors.MapTags(errors.Join(err1, err2), fn)
+
+	assert.NotContains(t, joined.Error(), "secret")
+	assert.Contains(t, joined.Error(), "test-error-1 where password=redacted")
+	assert.ErrorIs(t, joined, err2)
+
+	wrapped := cerrors.MapTags(fmt.Errorf("test-error-0: %w; %w", err1, err2), fn)
+
+	assert.Equal(t, `test-error-0 because
+> 2 errors occurred:
+* test-error-1 where password=redacted
+* test-error-2`, wrapped.Error())
+	assert.ErrorIs(t, wrapped, err2)
+
+	assert.NotContains(t, cerrors.WithoutTags(errors.Join(err1, err2)).Error(), "password")
+}
+
+func TestMapMessages(t *testing.T) {
+	t.Parallel()
+
+	var (
+		root = errors.New("token abc123 is invalid") //nolint:goerr113
+		err  = fmt.Errorf("failed with abc123: %w", cerrors.WithKind(
+			cerrors.New(root, "failed to verify abc123", map[string]interface{}{"id": 1}),
+			cerrors.KindUnauthorized,
+		))
+	)
+
+	out := cerrors.MapMessages(err, func(msg string) string {
+		return strings.ReplaceAll(msg, "abc123", "redacted")
+	})
+
+	assert.NotContains(t, out.Error(), "abc123")
+	assert.Equal(t, cerrors.KindUnauthorized, cerrors.KindOf(out))
+	assert.Equal(t, map[string]interface{}{"id": 1}, cerrors.Tags(out))
+	assert.Equal(t, "failed to verify redacted", cerrors.ChainOf(out)[1].Message)
+}
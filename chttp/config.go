@@ -26,4 +26,15 @@ type Config struct {
 	ReadTimeoutSeconds                 uint    `toml:"read_timeout_seconds" default:"10"`
 	RedirectURLForUnauthorizedRequests *string `toml:"redirect_url_for_unauthorized_requests"`
 	BasePath                           *string `toml:"base_path"`
+
+	// ExposeErrorChain includes the entire error chain in problem+json responses. It should only be enabled in
+	// development since the chain may contain internal details.
+	ExposeErrorChain bool `toml:"expose_error_chain"`
+
+	// LogLevelsEnabled adds the /_copper/log-levels admin endpoint that views and changes the app's log levels. The
+	// endpoint is protected with basic auth and is only added if LogLevelsBasicAuthUsername and
+	// LogLevelsBasicAuthPassword are set.
+	LogLevelsEnabled           bool   `toml:"log_levels_enabled"`
+	LogLevelsBasicAuthUsername string `toml:"log_levels_basic_auth_username"`
+	LogLevelsBasicAuthPassword string `toml:"log_levels_basic_auth_password"`
 }
@@ -0,0 +1,41 @@
+package chttp
+
+import (
+	"net/http"
+
+	"github.com/gocopper/copper/cerrors"
+)
+
+// StatusCodeForError returns the HTTP status code that represents the given error's cerrors.Kind. Errors without a
+// kind are treated as internal server errors.
+func StatusCodeForError(err error) int {
+	switch cerrors.KindOf(err) {
+	case cerrors.KindNotFound:
+		return http.StatusNotFound
+	case cerrors.KindInvalid:
+		return http.StatusBadRequest
+	case cerrors.KindConflict:
+		return http.StatusConflict
+	case cerrors.KindUnauthorized:
+		return http.StatusUnauthorized
+	case cerrors.KindForbidden:
+		return http.StatusForbidden
+	case cerrors.KindUnavailable:
+		return http.StatusServiceUnavailable
+	case cerrors.KindUnknown:
+		fallthrough
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// publicError returns the error's public code and message that are safe to write to a response. If the error does not
+// have a public message, the status text is used instead.
+func publicError(statusCode int, err error) (code, msg string) {
+	code, msg, ok := cerrors.Public(err)
+	if !ok || msg == "" {
+		msg = http.StatusText(statusCode)
+	}
+
+	return code, msg
+}
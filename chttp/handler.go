@@ -69,6 +69,7 @@ func NewHandler(p NewHandlerParams) http.Handler {
 		}
 
 		handler = setRoutePathInCtxMiddleware(routePath).Handle(handler)
+		handler = setLoggerInCtxMiddleware(p.Logger).Handle(handler)
 		handler = panicLoggerMiddleware(p.Logger).Handle(handler)
 
 		muxRoute := muxRouter.Handle(routePath, handler)
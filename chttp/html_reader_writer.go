@@ -51,8 +51,8 @@ func NewHTMLReaderWriter(html *HTMLRenderer, config Config, logger clogger.Logge
 	}
 }
 
-// WriteHTMLError handles the given error. In render_error is configured to true, it writes an HTML page with the error.
-// Errors are always logged.
+// WriteHTMLError handles the given error. In render_error is configured to true, it writes an HTML page with the error's
+// public message (see cerrors.WithPublic). Errors are always logged with their entire chain.
 func (rw *HTMLReaderWriter) WriteHTMLError(w http.ResponseWriter, r *http.Request, err error) {
 	rw.WriteHTML(w, r, WriteHTMLParams{
 		Error: err,
@@ -60,14 +60,15 @@ func (rw *HTMLReaderWriter) WriteHTMLError(w http.ResponseWriter, r *http.Reques
 }
 
 // WriteHTML writes an HTML response to the provided http.ResponseWriter. Using the given WriteHTMLParams, the HTML
-// is generated with a layout, page, and component templates.
+// is generated with a layout, page, and component templates. If an error is given without a status code, the status
+// code is derived from the error's cerrors.Kind.
 func (rw *HTMLReaderWriter) WriteHTML(w http.ResponseWriter, r *http.Request, p WriteHTMLParams) {
 	if p.StatusCode == 0 && p.Error == nil {
 		p.StatusCode = http.StatusOK
 	}
 
 	if p.StatusCode == 0 && p.Error != nil {
-		p.StatusCode = http.StatusInternalServerError
+		p.StatusCode = StatusCodeForError(p.Error)
 	}
 
 	if p.LayoutTemplate == "" {
@@ -94,8 +95,10 @@ func (rw *HTMLReaderWriter) WriteHTML(w http.ResponseWriter, r *http.Request, p
 
 		errorHTMLTmpl := template.Must(template.New("chtml/error.html").Parse(errorHTML))
 
+		_, msg := publicError(p.StatusCode, p.Error)
+
 		_ = errorHTMLTmpl.Execute(w, map[string]interface{}{
-			"Error": p.Error.Error(),
+			"Error": msg,
 		})
 
 		return
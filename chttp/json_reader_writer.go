@@ -18,6 +18,13 @@ type (
 		Data       interface{}
 	}
 
+	// WriteProblemParams holds the params for the WriteProblem function in JSONReaderWriter
+	WriteProblemParams struct {
+		StatusCode int
+		Type       string
+		Error      error
+	}
+
 	// JSONReaderWriter provides functions to read and write JSON data from/to HTTP requests/responses
 	JSONReaderWriter struct {
 		config Config
@@ -34,10 +41,18 @@ func NewJSONReaderWriter(config Config, logger clogger.Logger) *JSONReaderWriter
 }
 
 // WriteJSON writes a JSON response to the http.ResponseWriter. It can be configured with status code and data using
-// WriteJSONParams.
+// WriteJSONParams. If the data is an error and no status code is given, the status code is derived from the error's
+// cerrors.Kind. Errors are logged with their entire chain but only the public code and message (see cerrors.WithPublic)
+// are written to the response.
 func (rw *JSONReaderWriter) WriteJSON(w http.ResponseWriter, p WriteJSONParams) {
 	w.Header().Set("Content-Type", "application/json")
 
+	errData, ok := p.Data.(error)
+
+	if p.StatusCode == 0 && ok {
+		p.StatusCode = StatusCodeForError(errData)
+	}
+
 	if p.StatusCode > 0 {
 		w.WriteHeader(p.StatusCode)
 	}
@@ -46,11 +61,17 @@ func (rw *JSONReaderWriter) WriteJSON(w http.ResponseWriter, p WriteJSONParams)
 		return
 	}
 
-	errData, ok := p.Data.(error)
 	if ok {
-		err := json.NewEncoder(w).Encode(map[string]string{
-			"error": errData.Error(),
-		})
+		rw.logError(p.StatusCode, errData)
+
+		code, msg := publicError(p.StatusCode, errData)
+
+		body := map[string]string{"error": msg}
+		if code != "" {
+			body["code"] = code
+		}
+
+		err := json.NewEncoder(w).Encode(body)
 		if err != nil {
 			rw.logger.Error("Failed to marshal error response as json", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -68,6 +89,54 @@ func (rw *JSONReaderWriter) WriteJSON(w http.ResponseWriter, p WriteJSONParams)
 	}
 }
 
+// WriteProblem writes an RFC 7807 application/problem+json response for the given error. If no status code is given,
+// it is derived from the error's cerrors.Kind, or is 400 Bad Request if there is no error. The response includes the error's public code and message (see
+// cerrors.WithPublic). If expose_error_chain is configured to true, the response also includes the entire error chain
+// as returned by cerrors.ChainOf.
+func (rw *JSONReaderWriter) WriteProblem(w http.ResponseWriter, r *http.Request, p WriteProblemParams) {
+	if p.StatusCode == 0 && p.Error != nil {
+		p.StatusCode = StatusCodeForError(p.Error)
+	} else if p.StatusCode == 0 {
+		p.StatusCode = http.StatusBadRequest
+	}
+
+	if p.Type == "" {
+		p.Type = "about:blank"
+	}
+
+	problem := map[string]interface{}{
+		"type":     p.Type,
+		"title":    http.StatusText(p.StatusCode),
+		"status":   p.StatusCode,
+		"instance": r.URL.Path,
+	}
+
+	if p.Error != nil {
+		rw.logError(p.StatusCode, p.Error)
+
+		code, msg, ok := cerrors.Public(p.Error)
+		if ok && msg != "" {
+			problem["detail"] = msg
+		}
+
+		if code != "" {
+			problem["code"] = code
+		}
+
+		if rw.config.ExposeErrorChain {
+			problem["errors"] = cerrors.ChainOf(p.Error)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.StatusCode)
+
+	err := json.NewEncoder(w).Encode(problem)
+	if err != nil {
+		rw.logger.Error("Failed to marshal problem response as json", err)
+	}
+}
+
 // ReadJSON reads JSON from the http.Request into the body var. If the body struct has validate tags on it, the
 // struct is also validated. If the validation fails, a BadRequest response is sent back and the function returns
 // false.
@@ -85,9 +154,9 @@ func (rw *JSONReaderWriter) ReadJSON(w http.ResponseWriter, req *http.Request, b
 	} else if err != nil {
 		rw.WriteJSON(w, WriteJSONParams{
 			StatusCode: http.StatusBadRequest,
-			Data: cerrors.New(err, "invalid body json", map[string]interface{}{
+			Data: cerrors.WithPublic(cerrors.New(err, "invalid body json", map[string]interface{}{
 				"url": url,
-			}),
+			}), "invalid_json", "invalid body json"),
 		})
 
 		return false
@@ -95,13 +164,11 @@ func (rw *JSONReaderWriter) ReadJSON(w http.ResponseWriter, req *http.Request, b
 
 	ok, err := govalidator.ValidateStruct(body)
 	if !ok {
-		rw.logger.Warn("Failed to read body", cerrors.New(err, "data validation failed", map[string]interface{}{
-			"url": url,
-		}))
-
 		rw.WriteJSON(w, WriteJSONParams{
 			StatusCode: http.StatusBadRequest,
-			Data:       err,
+			Data: cerrors.WithPublic(cerrors.New(err, "data validation failed", map[string]interface{}{
+				"url": url,
+			}), "invalid_body", err.Error()),
 		})
 
 		return false
@@ -117,3 +184,12 @@ func (rw *JSONReaderWriter) Unauthorized(w http.ResponseWriter) {
 		Data:       map[string]string{"error": "unauthorized"},
 	})
 }
+
+func (rw *JSONReaderWriter) logError(statusCode int, err error) {
+	if statusCode >= http.StatusInternalServerError {
+		rw.logger.Error("Failed to handle request", err)
+		return
+	}
+
+	rw.logger.Warn("Failed to handle request", err)
+}
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/gocopper/copper/cerrors"
 	"github.com/gocopper/copper/chttp"
 	"github.com/gocopper/copper/clogger"
 	"github.com/stretchr/testify/assert"
@@ -143,7 +144,8 @@ func TestJSONReaderWriter_WriteJSON_Error(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, resp.Code)
 	assert.Equal(t, "application/json", resp.Header().Get("content-type"))
-	assert.Contains(t, resp.Body.String(), `{"error":"test-err"}`)
+	assert.Contains(t, resp.Body.String(), `{"error":"Bad Request"}`)
+	assert.NotContains(t, resp.Body.String(), "test-err")
 }
 
 func TestJSONReaderWriter_WriteJSON_NilData(t *testing.T) {
@@ -174,3 +176,115 @@ func TestJSONReaderWriter_Unauthorized(t *testing.T) {
 	assert.Equal(t, "application/json", resp.Header().Get("content-type"))
 	assert.Contains(t, resp.Body.String(), `{"error":"unauthorized"}`)
 }
+
+func TestJSONReaderWriter_WriteJSON_ErrorKind(t *testing.T) {
+	t.Parallel()
+
+	rw := chttp.NewJSONReaderWriter(chttp.Config{}, clogger.NewNoop())
+	resp := httptest.NewRecorder()
+
+	rw.WriteJSON(resp, chttp.WriteJSONParams{
+		Data: cerrors.New(cerrors.WithKind(errors.New("no rows"), cerrors.KindNotFound), "failed to get user", nil), //nolint:goerr113
+	})
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestJSONReaderWriter_WriteJSON_ErrorNoKind(t *testing.T) {
+	t.Parallel()
+
+	rw := chttp.NewJSONReaderWriter(chttp.Config{}, clogger.NewNoop())
+	resp := httptest.NewRecorder()
+
+	rw.WriteJSON(resp, chttp.WriteJSONParams{
+		Data: errors.New("test-err"), //nolint:goerr113
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+}
+
+func TestJSONReaderWriter_WriteJSON_PublicError(t *testing.T) {
+	t.Parallel()
+
+	rw := chttp.NewJSONReaderWriter(chttp.Config{}, clogger.NewNoop())
+	resp := httptest.NewRecorder()
+
+	rw.WriteJSON(resp, chttp.WriteJSONParams{
+		Data: cerrors.WithPublic(cerrors.WithKind(cerrors.New(errors.New("pq: duplicate key"), "failed to insert user", map[string]interface{}{ //nolint:goerr113
+			"email": "test@example.com",
+		}), cerrors.KindConflict), "email_taken", "email is already registered"),
+	})
+
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	assert.JSONEq(t, `{"error":"email is already registered","code":"email_taken"}`, resp.Body.String())
+}
+
+func TestJSONReaderWriter_WriteJSON_InternalError(t *testing.T) {
+	t.Parallel()
+
+	rw := chttp.NewJSONReaderWriter(chttp.Config{}, clogger.NewNoop())
+	resp := httptest.NewRecorder()
+
+	rw.WriteJSON(resp, chttp.WriteJSONParams{
+		Data: cerrors.New(errors.New("open /etc/app/secrets.toml: permission denied"), "failed to load secrets", nil), //nolint:goerr113
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.JSONEq(t, `{"error":"Internal Server Error"}`, resp.Body.String())
+}
+
+func TestJSONReaderWriter_WriteProblem(t *testing.T) {
+	t.Parallel()
+
+	rw := chttp.NewJSONReaderWriter(chttp.Config{}, clogger.NewNoop())
+	resp := httptest.NewRecorder()
+
+	rw.WriteProblem(resp, httptest.NewRequest(http.MethodGet, "/users/1", nil), chttp.WriteProblemParams{
+		Error: cerrors.WithPublic(cerrors.WithKind(cerrors.New(nil, "failed to get user", map[string]interface{}{
+			"id": 1,
+		}), cerrors.KindNotFound), "user_not_found", "user does not exist"),
+	})
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Equal(t, "application/problem+json", resp.Header().Get("content-type"))
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Not Found",
+		"status": 404,
+		"detail": "user does not exist",
+		"code": "user_not_found",
+		"instance": "/users/1"
+	}`, resp.Body.String())
+}
+
+func TestJSONReaderWriter_WriteProblem_ExposeErrorChain(t *testing.T) {
+	t.Parallel()
+
+	rw := chttp.NewJSONReaderWriter(chttp.Config{ExposeErrorChain: true}, clogger.NewNoop())
+	resp := httptest.NewRecorder()
+
+	rw.WriteProblem(resp, httptest.NewRequest(http.MethodGet, "/", nil), chttp.WriteProblemParams{
+		Error: cerrors.New(errors.New("test-cause"), "test-err", nil), //nolint:goerr113
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"message":"test-err"`)
+	assert.Contains(t, resp.Body.String(), `"message":"test-cause"`)
+}
+
+func TestJSONReaderWriter_WriteProblem_NoError(t *testing.T) {
+	t.Parallel()
+
+	rw := chttp.NewJSONReaderWriter(chttp.Config{}, clogger.NewNoop())
+	resp := httptest.NewRecorder()
+
+	rw.WriteProblem(resp, httptest.NewRequest(http.MethodGet, "/users", nil), chttp.WriteProblemParams{})
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Bad Request",
+		"status": 400,
+		"instance": "/users"
+	}`, resp.Body.String())
+}
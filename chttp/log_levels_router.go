@@ -0,0 +1,216 @@
+package chttp
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gocopper/copper/cerrors"
+	"github.com/gocopper/copper/clogger"
+)
+
+type (
+	// LogLevelsRouter provides an admin endpoint to view and change the app's log levels without restarting it.
+	// The endpoint is only added if it is enabled in Config along with the basic auth credentials that protect it.
+	LogLevelsRouter struct {
+		rw     *JSONReaderWriter
+		config Config
+		logger clogger.Logger
+	}
+
+	// NewLogLevelsRouterParams holds the params needed to instantiate a new LogLevelsRouter
+	NewLogLevelsRouterParams struct {
+		RW     *JSONReaderWriter
+		Config Config
+		Logger clogger.Logger
+	}
+
+	// LogLevels is the body of the log levels endpoint. In a request, a prefix with an empty level removes its
+	// override.
+	LogLevels struct {
+		Level    string            `json:"level,omitempty"`
+		Prefixes map[string]string `json:"prefixes,omitempty"`
+	}
+)
+
+// NewLogLevelsRouter instantiates a new LogLevelsRouter
+func NewLogLevelsRouter(p NewLogLevelsRouterParams) *LogLevelsRouter {
+	return &LogLevelsRouter{
+		rw:     p.RW,
+		config: p.Config,
+		logger: p.Logger,
+	}
+}
+
+// Routes defines the HTTP routes for this router. No routes are added if the endpoint is enabled without basic auth
+// credentials.
+func (ro *LogLevelsRouter) Routes() []Route {
+	if !ro.config.LogLevelsEnabled {
+		return []Route{}
+	}
+
+	if !ro.hasCredentials() {
+		ro.logger.Warn("[chttp] Log levels endpoint is enabled without basic auth credentials. Skipping..", nil)
+		return []Route{}
+	}
+
+	return []Route{
+		{
+			Path:    "/_copper/log-levels",
+			Methods: []string{http.MethodGet},
+			Handler: ro.HandleGetLogLevels,
+		},
+		{
+			Path:    "/_copper/log-levels",
+			Methods: []string{http.MethodPut},
+			Handler: ro.HandleSetLogLevels,
+		},
+	}
+}
+
+// HandleGetLogLevels writes the logger's current levels
+func (ro *LogLevelsRouter) HandleGetLogLevels(w http.ResponseWriter, r *http.Request) {
+	if !ro.authorized(w, r) {
+		return
+	}
+
+	levels, ok := ro.levels(w)
+	if !ok {
+		return
+	}
+
+	ro.rw.WriteJSON(w, WriteJSONParams{
+		Data: logLevelsBody(levels),
+	})
+}
+
+// HandleSetLogLevels changes the logger's levels. Levels that are not in the request body are left unchanged.
+func (ro *LogLevelsRouter) HandleSetLogLevels(w http.ResponseWriter, r *http.Request) {
+	var body LogLevels
+
+	if !ro.authorized(w, r) {
+		return
+	}
+
+	levels, ok := ro.levels(w)
+	if !ok {
+		return
+	}
+
+	if !ro.rw.ReadJSON(w, r, &body) {
+		return
+	}
+
+	// Parse all of the levels before changing any of them so that an invalid request does not apply partially
+	var (
+		min      clogger.Level
+		prefixes = make(map[string]clogger.Level, len(body.Prefixes))
+		err      error
+	)
+
+	if body.Level != "" {
+		min, err = clogger.ParseLevel(body.Level)
+		if err != nil {
+			ro.writeInvalidLevel(w, err)
+			return
+		}
+	}
+
+	for prefix, name := range body.Prefixes {
+		if name == "" {
+			continue
+		}
+
+		prefixes[prefix], err = clogger.ParseLevel(name)
+		if err != nil {
+			ro.writeInvalidLevel(w, err)
+			return
+		}
+	}
+
+	if min != 0 {
+		levels.SetMin(min)
+	}
+
+	for prefix, name := range body.Prefixes {
+		if name == "" {
+			levels.ResetPrefix(prefix)
+			continue
+		}
+
+		levels.SetPrefix(prefix, prefixes[prefix])
+	}
+
+	ro.logger.WithTags(map[string]any{
+		"level":    body.Level,
+		"prefixes": body.Prefixes,
+	}).Info("[chttp] Log levels changed")
+
+	ro.rw.WriteJSON(w, WriteJSONParams{
+		Data: logLevelsBody(levels),
+	})
+}
+
+// authorized checks the basic auth credentials and writes an unauthorized response if they do not match. Requests are
+// always refused if the credentials are not configured.
+func (ro *LogLevelsRouter) authorized(w http.ResponseWriter, r *http.Request) bool {
+	if !ro.hasCredentials() {
+		w.WriteHeader(http.StatusForbidden)
+		return false
+	}
+
+	username, password, ok := r.BasicAuth()
+
+	usernameOK := subtle.ConstantTimeCompare([]byte(username), []byte(ro.config.LogLevelsBasicAuthUsername)) == 1
+	passwordOK := subtle.ConstantTimeCompare([]byte(password), []byte(ro.config.LogLevelsBasicAuthPassword)) == 1
+
+	if ok && usernameOK && passwordOK {
+		return true
+	}
+
+	w.Header().Set("WWW-Authenticate", `Basic realm="log-levels"`)
+	w.WriteHeader(http.StatusUnauthorized)
+
+	return false
+}
+
+func (ro *LogLevelsRouter) hasCredentials() bool {
+	return ro.config.LogLevelsBasicAuthUsername != "" && ro.config.LogLevelsBasicAuthPassword != ""
+}
+
+func (ro *LogLevelsRouter) levels(w http.ResponseWriter) (*clogger.Levels, bool) {
+	levels, ok := clogger.LevelsOf(ro.logger)
+	if !ok {
+		ro.rw.WriteJSON(w, WriteJSONParams{
+			StatusCode: http.StatusNotImplemented,
+			Data: cerrors.WithPublic(cerrors.New(nil, "logger does not support runtime levels", nil),
+				"log_levels_unsupported", "logger does not support runtime levels"),
+		})
+
+		return nil, false
+	}
+
+	return levels, true
+}
+
+func (ro *LogLevelsRouter) writeInvalidLevel(w http.ResponseWriter, err error) {
+	ro.rw.WriteJSON(w, WriteJSONParams{
+		Data: cerrors.WithPublic(cerrors.WithKind(err, cerrors.KindInvalid), "invalid_log_level", err.Error()),
+	})
+}
+
+func logLevelsBody(levels *clogger.Levels) LogLevels {
+	prefixes := make(map[string]string)
+	for prefix, lvl := range levels.Prefixes() {
+		prefixes[prefix] = levelName(lvl)
+	}
+
+	return LogLevels{
+		Level:    levelName(levels.Min()),
+		Prefixes: prefixes,
+	}
+}
+
+func levelName(lvl clogger.Level) string {
+	name, _ := lvl.MarshalText()
+	return string(name)
+}
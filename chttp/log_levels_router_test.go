@@ -0,0 +1,152 @@
+package chttp_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gocopper/copper/chttp"
+	"github.com/gocopper/copper/clogger"
+	"github.com/stretchr/testify/assert"
+)
+
+//nolint:gochecknoglobals
+var testLogLevelsConfig = chttp.Config{
+	LogLevelsEnabled:           true,
+	LogLevelsBasicAuthUsername: "test-user",
+	LogLevelsBasicAuthPassword: "test-pass",
+}
+
+func newLogLevelsRequest(method, body string) *http.Request {
+	req := httptest.NewRequest(method, "/_copper/log-levels", bytes.NewReader([]byte(body)))
+	req.SetBasicAuth(testLogLevelsConfig.LogLevelsBasicAuthUsername, testLogLevelsConfig.LogLevelsBasicAuthPassword)
+
+	return req
+}
+
+func TestLogLevelsRouter(t *testing.T) {
+	t.Parallel()
+
+	logger, err := clogger.New(clogger.Config{Level: "info"}, nil)
+	assert.NoError(t, err)
+
+	handler := chttp.NewHandler(chttp.NewHandlerParams{
+		Routers: []chttp.Router{chttp.NewLogLevelsRouter(chttp.NewLogLevelsRouterParams{
+			RW:     chttp.NewJSONReaderWriter(chttp.Config{}, clogger.NewNoop()),
+			Config: testLogLevelsConfig,
+			Logger: logger,
+		})},
+		Logger: clogger.NewNoop(),
+	})
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, newLogLevelsRequest(http.MethodPut, `{"level":"warn","prefixes":{"[csql]":"error"}}`))
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"level":"warn","prefixes":{"csql":"error"}}`, resp.Body.String())
+
+	levels, _ := clogger.LevelsOf(logger)
+	assert.Equal(t, clogger.LevelWarn, levels.Min())
+
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, newLogLevelsRequest(http.MethodPut, `{"level":"verbose","prefixes":{"csql":""}}`))
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "invalid_log_level")
+
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, newLogLevelsRequest(http.MethodGet, ""))
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"level":"warn","prefixes":{"csql":"error"}}`, resp.Body.String())
+}
+
+func TestLogLevelsRouter_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	handler := chttp.NewHandler(chttp.NewHandlerParams{
+		Routers: []chttp.Router{chttp.NewLogLevelsRouter(chttp.NewLogLevelsRouterParams{
+			RW:     chttp.NewJSONReaderWriter(chttp.Config{}, clogger.NewNoop()),
+			Config: testLogLevelsConfig,
+			Logger: clogger.NewNoop(),
+		})},
+		Logger: clogger.NewNoop(),
+	})
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, newLogLevelsRequest(http.MethodGet, ""))
+
+	assert.Equal(t, http.StatusNotImplemented, resp.Code)
+}
+
+func TestLogLevelsRouter_Disabled(t *testing.T) {
+	t.Parallel()
+
+	router := chttp.NewLogLevelsRouter(chttp.NewLogLevelsRouterParams{
+		RW:     chttp.NewJSONReaderWriter(chttp.Config{}, clogger.NewNoop()),
+		Config: chttp.Config{},
+		Logger: clogger.NewNoop(),
+	})
+
+	assert.Empty(t, router.Routes())
+}
+
+func TestLogLevelsRouter_BasicAuth(t *testing.T) {
+	t.Parallel()
+
+	logger, err := clogger.New(clogger.Config{Level: "info"}, nil)
+	assert.NoError(t, err)
+
+	router := chttp.NewLogLevelsRouter(chttp.NewLogLevelsRouterParams{
+		RW: chttp.NewJSONReaderWriter(chttp.Config{}, clogger.NewNoop()),
+		Config: chttp.Config{
+			LogLevelsEnabled:           true,
+			LogLevelsBasicAuthUsername: "test-user",
+			LogLevelsBasicAuthPassword: "test-pass",
+		},
+		Logger: logger,
+	})
+
+	resp := httptest.NewRecorder()
+	router.HandleSetLogLevels(resp, httptest.NewRequest(http.MethodPut, "/_copper/log-levels",
+		bytes.NewReader([]byte(`{"level":"error"}`))))
+
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+
+	levels, _ := clogger.LevelsOf(logger)
+	assert.Equal(t, clogger.LevelInfo, levels.Min())
+
+	req := httptest.NewRequest(http.MethodPut, "/_copper/log-levels", bytes.NewReader([]byte(`{"level":"error"}`)))
+	req.SetBasicAuth("test-user", "test-pass")
+
+	resp = httptest.NewRecorder()
+	router.HandleSetLogLevels(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, clogger.LevelError, levels.Min())
+}
+
+func TestLogLevelsRouter_NoCredentials(t *testing.T) {
+	t.Parallel()
+
+	logger, err := clogger.New(clogger.Config{Level: "info"}, nil)
+	assert.NoError(t, err)
+
+	router := chttp.NewLogLevelsRouter(chttp.NewLogLevelsRouterParams{
+		RW:     chttp.NewJSONReaderWriter(chttp.Config{}, clogger.NewNoop()),
+		Config: chttp.Config{LogLevelsEnabled: true},
+		Logger: logger,
+	})
+
+	assert.Empty(t, router.Routes())
+
+	resp := httptest.NewRecorder()
+	router.HandleSetLogLevels(resp, httptest.NewRequest(http.MethodPut, "/_copper/log-levels",
+		bytes.NewReader([]byte(`{"level":"error"}`))))
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+
+	levels, _ := clogger.LevelsOf(logger)
+	assert.Equal(t, clogger.LevelInfo, levels.Min())
+}
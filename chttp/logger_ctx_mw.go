@@ -0,0 +1,100 @@
+package chttp
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gocopper/copper/clogger"
+)
+
+type ctxTraceParent string
+
+const ctxTraceParentKey = ctxTraceParent("chttp/traceparent")
+
+// traceParent holds the ids parsed from a W3C traceparent header.
+type traceParent struct {
+	traceID string
+	spanID  string
+}
+
+// setLoggerInCtxMiddleware stores the logger in the request context so that it can be retrieved with
+// clogger.FromContext. It also stores the trace ids from the traceparent header, if present.
+func setLoggerInCtxMiddleware(logger clogger.Logger) Middleware {
+	var mw = func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := clogger.WithContext(r.Context(), logger)
+
+			if tp, ok := parseTraceParent(r.Header.Get("traceparent")); ok {
+				ctx = context.WithValue(ctx, ctxTraceParentKey, tp)
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+
+	return HandleMiddleware(mw)
+}
+
+// GetTraceID returns the trace id from the request's traceparent header. If the request did not have a valid
+// traceparent header, it returns an empty string.
+func GetTraceID(ctx context.Context) string {
+	tp, _ := ctx.Value(ctxTraceParentKey).(traceParent)
+	return tp.traceID
+}
+
+func init() { //nolint:gochecknoinits
+	// The extractor only depends on the context, so it is registered once for the process instead of per app
+	clogger.RegisterContextExtractor("chttp", extractLogTags)
+}
+
+// extractLogTags is a clogger.ContextExtractor that adds the request's metadata to logs.
+func extractLogTags(ctx context.Context) map[string]any {
+	tags := make(map[string]any)
+
+	if requestID := GetRequestID(ctx); requestID != "" {
+		tags["request_id"] = requestID
+	}
+
+	if routePath, ok := ctx.Value(ctxRoutePathKey).(string); ok {
+		tags["route"] = routePath
+	}
+
+	if tp, ok := ctx.Value(ctxTraceParentKey).(traceParent); ok {
+		tags["trace_id"] = tp.traceID
+		tags["span_id"] = tp.spanID
+	}
+
+	return tags
+}
+
+// parseTraceParent parses a W3C traceparent header (ex. 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01).
+func parseTraceParent(header string) (traceParent, bool) {
+	const (
+		traceIDLen = 32
+		spanIDLen  = 16
+	)
+
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return traceParent{}, false
+	}
+
+	traceID, spanID := parts[1], parts[2]
+	if len(traceID) != traceIDLen || len(spanID) != spanIDLen || !isHex(traceID) || !isHex(spanID) ||
+		strings.Trim(traceID, "0") == "" || strings.Trim(spanID, "0") == "" {
+		return traceParent{}, false
+	}
+
+	return traceParent{traceID: traceID, spanID: spanID}, true
+}
+
+func isHex(s string) bool {
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+
+	return true
+}
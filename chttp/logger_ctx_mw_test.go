@@ -0,0 +1,72 @@
+package chttp_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gocopper/copper/chttp"
+	"github.com/gocopper/copper/chttp/chttptest"
+	"github.com/gocopper/copper/clogger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHandler_LoggerInCtx(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	router := chttptest.NewRouter([]chttp.Route{
+		{
+			Path: "/users/{id}",
+			Handler: func(w http.ResponseWriter, r *http.Request) {
+				clogger.FromContext(r.Context()).Info("test-msg")
+			},
+		},
+	})
+
+	handler := chttp.NewHandler(chttp.NewHandlerParams{
+		Routers:           []chttp.Router{router},
+		GlobalMiddlewares: []chttp.Middleware{chttp.SetRequestIDInCtxMiddleware()},
+		Logger:            clogger.NewWithWriters(&buf, &buf, clogger.FormatPlain, nil, nil, nil),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Contains(t, buf.String(), "test-msg")
+	assert.Contains(t, buf.String(), "request_id=")
+	assert.Contains(t, buf.String(), "route=/users/{id}")
+	assert.Contains(t, buf.String(), "trace_id=4bf92f3577b34da6a3ce929d0e0e4736")
+	assert.Contains(t, buf.String(), "span_id=00f067aa0ba902b7")
+}
+
+func TestGetTraceID_InvalidHeader(t *testing.T) {
+	t.Parallel()
+
+	var traceID string
+
+	router := chttptest.NewRouter([]chttp.Route{
+		{
+			Path: "/",
+			Handler: func(w http.ResponseWriter, r *http.Request) {
+				traceID = chttp.GetTraceID(r.Context())
+			},
+		},
+	})
+
+	handler := chttp.NewHandler(chttp.NewHandlerParams{
+		Routers: []chttp.Router{router},
+		Logger:  clogger.NewNoop(),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("traceparent", "00-00000000000000000000000000000000-00f067aa0ba902b7-01")
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Empty(t, traceID)
+}
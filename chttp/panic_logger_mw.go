@@ -1,9 +1,11 @@
 package chttp
 
 import (
+	"fmt"
 	"net/http"
 	"runtime/debug"
 
+	"github.com/gocopper/copper/cerrors"
 	"github.com/gocopper/copper/clogger"
 )
 
@@ -21,13 +23,12 @@ func panicLoggerMiddleware(logger clogger.Logger) Middleware {
 				case error:
 					log.WithTags(map[string]interface{}{
 						"stack": string(debug.Stack()),
-					}).Error("Recovered from a panic while handling HTTP request", r)
+					}).Error("Recovered from a panic while handling HTTP request", cerrors.WithStack(r))
 					w.WriteHeader(http.StatusInternalServerError)
 				default:
 					log.WithTags(map[string]interface{}{
-						"error": r,
 						"stack": string(debug.Stack()),
-					}).Error("Recovered from a panic while handling HTTP request", nil)
+					}).Error("Recovered from a panic while handling HTTP request", cerrors.New(nil, fmt.Sprint(r), nil))
 					w.WriteHeader(http.StatusInternalServerError)
 				}
 			}()
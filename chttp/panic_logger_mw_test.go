@@ -46,7 +46,7 @@ func TestPanicLoggerMiddleware_PanicError(t *testing.T) {
 	assert.Equal(t, 1, len(logs))
 	assert.Equal(t, "Recovered from a panic while handling HTTP request", logs[0].Msg)
 	assert.Equal(t, clogger.LevelError, logs[0].Level)
-	assert.Equal(t, errors.New("test-error"), logs[0].Error)
+	assert.EqualError(t, logs[0].Error, "test-error")
 	assert.Contains(t, logs[0].Tags["stack"], "panic_logger_mw.go")
 }
 
@@ -116,7 +116,6 @@ func TestPanicLoggerMiddleware_PanicNonError(t *testing.T) {
 	assert.Equal(t, 1, len(logs))
 	assert.Equal(t, "Recovered from a panic while handling HTTP request", logs[0].Msg)
 	assert.Equal(t, clogger.LevelError, logs[0].Level)
-	assert.Equal(t, "test-error", logs[0].Tags["error"])
-	assert.Nil(t, logs[0].Error)
+	assert.EqualError(t, logs[0].Error, "test-error")
 	assert.Contains(t, logs[0].Tags["stack"], "panic_logger_mw.go")
 }
@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
-	"github.com/gocopper/copper/cmetrics"
 	"net"
 	"net/http"
 	"time"
@@ -14,8 +13,15 @@ import (
 
 var errRWIsNotHijacker = errors.New("internal response writer is not http.Hijacker")
 
+// RequestMetrics records the count and duration of requests. It is implemented by cmetrics.Metrics, which is bound to
+// it by cmetrics.WireModule.
+type RequestMetrics interface {
+	CounterInc(name string, labels map[string]string)
+	HistogramObserve(name string, labels map[string]string, value float64)
+}
+
 // NewRequestLoggerMiddleware creates a new RequestLoggerMiddleware.
-func NewRequestLoggerMiddleware(metrics cmetrics.Metrics, logger clogger.Logger) *RequestLoggerMiddleware {
+func NewRequestLoggerMiddleware(metrics RequestMetrics, logger clogger.Logger) *RequestLoggerMiddleware {
 	return &RequestLoggerMiddleware{
 		metrics: metrics,
 		logger:  logger,
@@ -25,7 +31,7 @@ func NewRequestLoggerMiddleware(metrics cmetrics.Metrics, logger clogger.Logger)
 // RequestLoggerMiddleware logs each request's HTTP method, path, and status code along with user uuid
 // (from basic auth) if any.
 type RequestLoggerMiddleware struct {
-	metrics cmetrics.Metrics
+	metrics RequestMetrics
 	logger  clogger.Logger
 }
 
@@ -37,7 +37,7 @@ func TestServer_Run(t *testing.T) {
 	assert.NoError(t, resp.Body.Close())
 	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 
-	lc.Stop(clogger.NewNoop())
+	assert.NoError(t, lc.Stop(clogger.NewNoop()))
 
 	time.Sleep(50 * time.Millisecond) // wait for server to stop
 
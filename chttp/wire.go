@@ -14,6 +14,8 @@ var WireModule = wire.NewSet( //nolint:gochecknoglobals
 	NewServer,
 	wire.Struct(new(NewHTMLRouterParams), "*"),
 	NewHTMLRouter,
+	wire.Struct(new(NewLogLevelsRouterParams), "*"),
+	NewLogLevelsRouter,
 	wire.Struct(new(NewHTMLRendererParams), "*"),
 	NewHTMLRenderer,
 )
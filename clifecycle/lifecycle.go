@@ -2,23 +2,33 @@ package clifecycle
 
 import (
 	"context"
+	"fmt"
 	"runtime/debug"
 	"sync"
 	"time"
 
+	"github.com/gocopper/copper/cerrors"
 	"github.com/gocopper/copper/clogger"
 )
 
 const defaultStopTimeout = 30 * time.Second
 
+// PanicMsg is the message of the error logged when a goroutine started with Go panics
+const PanicMsg = "[copper] Panic in goroutine"
+
+// PanicTag is set to true in the tags of the error logged when a goroutine started with Go panics so that hooks can
+// tell it apart from other errors.
+const PanicTag = "panic"
+
 func New(logger clogger.CoreLogger) *Lifecycle {
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(clogger.WithContext(context.Background(), logger))
 
 	return &Lifecycle{
 		Context:     ctx,
 		logger:      logger,
 		cancel:      cancel,
 		onStop:      make([]func(ctx context.Context) error, 0),
+		onPanic:     make([]func(err error), 0),
 		stopTimeout: defaultStopTimeout,
 	}
 }
@@ -28,6 +38,7 @@ type Lifecycle struct {
 	logger      clogger.CoreLogger
 	cancel      context.CancelFunc
 	onStop      []func(ctx context.Context) error
+	onPanic     []func(err error)
 	stopTimeout time.Duration
 	wg          sync.WaitGroup
 }
@@ -39,8 +50,16 @@ func (lc *Lifecycle) OnStop(fn func(ctx context.Context) error) {
 	lc.onStop = append(lc.onStop, fn)
 }
 
+// OnPanic registers the provided fn to run when a goroutine started with Go
+// panics. The fn is given an error that wraps the recovered value along with
+// the stack trace of the panic.
+func (lc *Lifecycle) OnPanic(fn func(err error)) {
+	lc.onPanic = append(lc.onPanic, fn)
+}
+
 // Go starts a background goroutine that will be waited for during shutdown.
 // The goroutine should return when the context is done or when its work is complete.
+// The context holds the app's logger, which can be retrieved with clogger.FromContext.
 //
 // WARNING: Be careful with closure capture in loops. Make copies of loop variables:
 //
@@ -53,22 +72,31 @@ func (lc *Lifecycle) OnStop(fn func(ctx context.Context) error) {
 func (lc *Lifecycle) Go(fn func(ctx context.Context)) {
 	lc.wg.Add(1)
 	go func() {
+		// Mark the goroutine as done only after a panic has been handled
+		defer lc.wg.Done()
 		defer func() {
 			if r := recover(); r != nil {
+				err := panicErr(r)
+
 				lc.logger.WithTags(map[string]interface{}{
-					"error": r,
-					"stack": string(debug.Stack()),
-				}).Error("[copper] Panic in goroutine", nil)
+					"stack":  string(debug.Stack()),
+					PanicTag: true,
+				}).Error(PanicMsg, err)
+
+				for _, fn := range lc.onPanic {
+					fn(err)
+				}
 			}
 		}()
-		defer lc.wg.Done()
 		fn(lc.Context)
 	}()
 }
 
 // Stop runs all of the registered stop funcs in order along with a
 // context with a configured timeout and waits for them to complete.
-func (lc *Lifecycle) Stop(logger Logger) {
+// Failures from each of the stop funcs, and the background goroutines
+// not completing in time, are joined into the returned error.
+func (lc *Lifecycle) Stop(logger Logger) error {
 	// Cancel context first so goroutines know to stop
 	lc.cancel()
 
@@ -76,11 +104,13 @@ func (lc *Lifecycle) Stop(logger Logger) {
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), lc.stopTimeout)
 	defer cancel()
 
+	errs := make([]error, 0)
+
 	// Run cleanup functions (HTTP server shutdown, etc.)
 	for _, fn := range lc.onStop {
 		err := fn(shutdownCtx)
 		if err != nil {
-			logger.Error("Failed to run cleanup func", err)
+			errs = append(errs, cerrors.New(err, "failed to run cleanup func", nil))
 		}
 	}
 
@@ -95,6 +125,17 @@ func (lc *Lifecycle) Stop(logger Logger) {
 	case <-done:
 		logger.Info("All background jobs completed successfully")
 	case <-shutdownCtx.Done():
-		logger.Error("Background jobs did not complete within timeout", shutdownCtx.Err())
+		errs = append(errs, cerrors.New(shutdownCtx.Err(), "background jobs did not complete within timeout", nil))
 	}
+
+	return cerrors.Join(errs...)
+}
+
+// panicErr converts a value recovered from a panic into an error that holds the stack trace of the panic.
+func panicErr(r interface{}) error {
+	if err, ok := r.(error); ok {
+		return cerrors.WithStack(err)
+	}
+
+	return cerrors.New(nil, fmt.Sprint(r), nil)
 }
@@ -0,0 +1,55 @@
+package clifecycle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gocopper/copper/clifecycle"
+	"github.com/gocopper/copper/clogger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLifecycle_Stop(t *testing.T) {
+	t.Parallel()
+
+	var (
+		lc     = clifecycle.New(clogger.NewNoop())
+		didRun = false
+	)
+
+	lc.OnStop(func(ctx context.Context) error {
+		didRun = true
+		return nil
+	})
+
+	assert.NoError(t, lc.Stop(clogger.NewNoop()))
+	assert.True(t, didRun)
+}
+
+func TestLifecycle_Stop_Errors(t *testing.T) {
+	t.Parallel()
+
+	var (
+		lc   = clifecycle.New(clogger.NewNoop())
+		err1 = errors.New("test-err-1") //nolint:goerr113
+		err2 = errors.New("test-err-2") //nolint:goerr113
+		runs = 0
+	)
+
+	lc.OnStop(func(ctx context.Context) error {
+		runs++
+		return err1
+	})
+
+	lc.OnStop(func(ctx context.Context) error {
+		runs++
+		return err2
+	})
+
+	err := lc.Stop(clogger.NewNoop())
+
+	assert.Equal(t, 2, runs)
+	assert.ErrorIs(t, err, err1)
+	assert.ErrorIs(t, err, err2)
+}
@@ -0,0 +1,185 @@
+package clogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// Policies supported by the async writer when its buffer is full
+const (
+	AsyncOverflowDrop  = "drop"
+	AsyncOverflowBlock = "block"
+)
+
+// asyncWriter writes lines to the underlying writer from a background goroutine. Lines are buffered in a bounded
+// ring buffer. When the buffer is full, lines are either dropped or the caller blocks until there is space.
+//
+// Each call to Write must be a complete log line since lines from concurrent writers are not interleaved.
+type asyncWriter struct {
+	w     io.Writer
+	block bool
+
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	changed  *sync.Cond
+	lines    [][]byte
+	head     int
+	count    int
+	writing  bool
+	closed   bool
+
+	dropped atomic.Uint64
+	done    chan struct{}
+}
+
+func newAsyncWriter(w io.Writer, size int, overflow string) *asyncWriter {
+	if size <= 0 {
+		size = 1
+	}
+
+	aw := &asyncWriter{
+		w:     w,
+		block: overflow == AsyncOverflowBlock,
+		lines: make([][]byte, size),
+		done:  make(chan struct{}),
+	}
+
+	aw.notEmpty = sync.NewCond(&aw.mu)
+	aw.changed = sync.NewCond(&aw.mu)
+
+	go aw.run()
+
+	return aw
+}
+
+// Write buffers a copy of p to be written by the background goroutine. Once the writer is closed, p is written
+// synchronously instead.
+func (aw *asyncWriter) Write(p []byte) (int, error) {
+	aw.mu.Lock()
+
+	for !aw.closed && aw.count == len(aw.lines) {
+		if !aw.block {
+			aw.mu.Unlock()
+			aw.dropped.Add(1)
+
+			return len(p), nil
+		}
+
+		aw.changed.Wait()
+	}
+
+	if aw.closed {
+		aw.mu.Unlock()
+		return aw.w.Write(p) //nolint:wrapcheck
+	}
+
+	aw.lines[(aw.head+aw.count)%len(aw.lines)] = append([]byte(nil), p...)
+	aw.count++
+
+	aw.notEmpty.Signal()
+	aw.mu.Unlock()
+
+	return len(p), nil
+}
+
+// Flush waits until all of the buffered lines have been written.
+func (aw *asyncWriter) Flush() {
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+
+	for aw.count > 0 || aw.writing {
+		aw.changed.Wait()
+	}
+}
+
+// Close writes the buffered lines and stops the background goroutine. Lines written after Close are written
+// synchronously.
+func (aw *asyncWriter) Close() error {
+	aw.mu.Lock()
+	if aw.closed {
+		aw.mu.Unlock()
+		return nil
+	}
+
+	aw.closed = true
+	aw.notEmpty.Broadcast()
+	aw.changed.Broadcast()
+	aw.mu.Unlock()
+
+	<-aw.done
+
+	return nil
+}
+
+// Dropped returns the number of lines that were dropped because the buffer was full.
+func (aw *asyncWriter) Dropped() uint64 {
+	return aw.dropped.Load()
+}
+
+func (aw *asyncWriter) run() {
+	defer close(aw.done)
+
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+
+	for {
+		for aw.count == 0 && !aw.closed {
+			aw.notEmpty.Wait()
+		}
+
+		if aw.count == 0 {
+			return
+		}
+
+		line := aw.lines[aw.head]
+		aw.lines[aw.head] = nil
+		aw.head = (aw.head + 1) % len(aw.lines)
+		aw.count--
+		aw.writing = true
+
+		aw.mu.Unlock()
+		_, _ = aw.w.Write(line)
+		aw.mu.Lock()
+
+		aw.writing = false
+		aw.changed.Broadcast()
+	}
+}
+
+// lineBuffer holds a reusable buffer and JSON encoder for formatting a log line.
+type lineBuffer struct {
+	buf bytes.Buffer
+	enc *json.Encoder
+}
+
+var lineBuffers = sync.Pool{ //nolint:gochecknoglobals
+	New: func() any {
+		lb := &lineBuffer{}
+
+		lb.enc = json.NewEncoder(&lb.buf)
+		lb.enc.SetEscapeHTML(false)
+
+		return lb
+	},
+}
+
+func getLineBuffer() *lineBuffer {
+	lb := lineBuffers.Get().(*lineBuffer) //nolint:forcetypeassert
+	lb.buf.Reset()
+
+	return lb
+}
+
+func putLineBuffer(lb *lineBuffer) {
+	const maxPooledLineSize = 64 * 1024
+
+	// Avoid holding on to the memory of unusually large lines
+	if lb.buf.Cap() > maxPooledLineSize {
+		return
+	}
+
+	lineBuffers.Put(lb)
+}
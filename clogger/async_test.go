@@ -0,0 +1,70 @@
+package clogger_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gocopper/copper/clogger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_Async(t *testing.T) {
+	t.Parallel()
+
+	out := filepath.Join(t.TempDir(), "out.log")
+
+	logger, err := clogger.New(clogger.Config{
+		Out:             out,
+		Err:             out,
+		Async:           true,
+		AsyncBufferSize: 16,
+		AsyncOverflow:   clogger.AsyncOverflowBlock,
+	}, nil)
+	assert.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		logger.Info("test-msg")
+	}
+
+	closer, ok := logger.(interface{ Close() error })
+	assert.True(t, ok)
+	assert.NoError(t, closer.Close())
+
+	logs, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	assert.Equal(t, 100, strings.Count(string(logs), "test-msg"))
+}
+
+func TestNew_AsyncDrop(t *testing.T) {
+	t.Parallel()
+
+	const n = 1000
+
+	out := filepath.Join(t.TempDir(), "out.log")
+
+	logger, err := clogger.New(clogger.Config{
+		Out:             out,
+		Err:             out,
+		Async:           true,
+		AsyncBufferSize: 1,
+		AsyncOverflow:   clogger.AsyncOverflowDrop,
+	}, nil)
+	assert.NoError(t, err)
+
+	for i := 0; i < n; i++ {
+		logger.Error("test-msg", nil)
+	}
+
+	impl, ok := logger.(*clogger.LoggerImpl)
+	assert.True(t, ok)
+
+	impl.Flush()
+	dropped := impl.DroppedLines()
+	assert.NoError(t, impl.Close())
+
+	logs, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	assert.Equal(t, n, strings.Count(string(logs), "test-msg")+int(dropped))
+}
@@ -0,0 +1,322 @@
+package clogger
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/gocopper/copper/cerrors"
+)
+
+// Defaults used by BatchHook for the options that are not set
+const (
+	defaultBatchQueueSize     = 1024
+	defaultBatchSize          = 100
+	defaultBatchFlushInterval = time.Second
+	defaultBatchRetryBackoff  = 100 * time.Millisecond
+	defaultBatchSendTimeout   = 10 * time.Second
+)
+
+// Record is a log forwarded by BatchHook to a BatchSender.
+type Record struct {
+	Time  time.Time
+	Level Level
+	Msg   string
+	Tags  map[string]any
+	Err   error
+}
+
+// BatchSender sends a batch of records to a log destination (ex. syslog or a collector).
+type BatchSender interface {
+	Send(ctx context.Context, records []Record) error
+}
+
+// BatchOptions configures BatchHook. Options that are not set use their defaults.
+type BatchOptions struct {
+	// QueueSize is the number of records that can be waiting to be sent. New records are dropped when it is full.
+	QueueSize int
+
+	// BatchSize is the max number of records sent at once
+	BatchSize int
+
+	// FlushInterval is how often queued records are sent if the batch is not full
+	FlushInterval time.Duration
+
+	// MaxRetries is the number of times a failed batch is retried, waiting twice as long as the previous attempt
+	// starting with RetryBackoff
+	MaxRetries   int
+	RetryBackoff time.Duration
+
+	// SendTimeout limits how long each attempt to send a batch can take
+	SendTimeout time.Duration
+}
+
+// BatchStats holds the counts of records handled by BatchHook
+type BatchStats struct {
+	Sent    int
+	Dropped int
+	Failed  int
+}
+
+// BatchHook is a Hook that queues logs and sends them to a BatchSender in batches from a background goroutine.
+// Failed batches are retried. Records are dropped instead of blocking the logger if the queue is full.
+type BatchHook struct {
+	sender BatchSender
+	opts   BatchOptions
+	redact func(r Record) Record
+
+	mu      sync.Mutex
+	stats   BatchStats
+	closed  bool
+	records chan Record
+	flushes chan chan struct{}
+	done    chan struct{}
+}
+
+// permanentError marks an error that will not go away by retrying (ex. the destination rejected the request).
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e permanentError) Unwrap() error {
+	return e.err
+}
+
+// partialError marks an error that happened after the first sent records of a batch were delivered, so that only the
+// remaining records are retried.
+type partialError struct {
+	sent int
+	err  error
+}
+
+func (e partialError) Error() string {
+	return e.err.Error()
+}
+
+func (e partialError) Unwrap() error {
+	return e.err
+}
+
+// NewBatchHook creates a BatchHook and starts sending logs to the given sender in the background. Close should be
+// called to send the pending logs and stop the goroutine.
+func NewBatchHook(sender BatchSender, opts BatchOptions) *BatchHook {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = defaultBatchQueueSize
+	}
+
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = defaultBatchFlushInterval
+	}
+
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = defaultBatchRetryBackoff
+	}
+
+	if opts.SendTimeout <= 0 {
+		opts.SendTimeout = defaultBatchSendTimeout
+	}
+
+	h := &BatchHook{
+		sender:  sender,
+		opts:    opts,
+		records: make(chan Record, opts.QueueSize),
+		flushes: make(chan chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	go h.run()
+
+	return h
+}
+
+// OnLog implements Hook by queueing the log to be sent.
+func (h *BatchHook) OnLog(level Level, msg string, tags map[string]any, err error) {
+	r := Record{
+		Time:  time.Now(),
+		Level: level,
+		Msg:   msg,
+		Tags:  tags,
+		Err:   err,
+	}
+
+	if h.redact != nil {
+		r = h.redact(r)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+
+	select {
+	case h.records <- r:
+	default:
+		h.stats.Dropped++
+	}
+}
+
+// Stats returns the counts of records that have been handled so far.
+func (h *BatchHook) Stats() BatchStats {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.stats
+}
+
+// Flush sends the queued records and waits until they have been sent or the given context is done.
+func (h *BatchHook) Flush(ctx context.Context) error {
+	h.mu.Lock()
+	closed := h.closed
+	h.mu.Unlock()
+
+	if closed {
+		return nil
+	}
+
+	ack := make(chan struct{})
+
+	select {
+	case h.flushes <- ack:
+	case <-h.done:
+		return nil
+	case <-ctx.Done():
+		return cerrors.New(ctx.Err(), "failed to flush logs", nil)
+	}
+
+	select {
+	case <-ack:
+		return nil
+	case <-ctx.Done():
+		return cerrors.New(ctx.Err(), "failed to flush logs", nil)
+	}
+}
+
+// Close stops accepting new records and waits for the queued records to be sent until the given context is done. If
+// the sender is an io.Closer, it is closed once the records have been sent.
+func (h *BatchHook) Close(ctx context.Context) error {
+	h.mu.Lock()
+	if !h.closed {
+		h.closed = true
+		close(h.records)
+	}
+	h.mu.Unlock()
+
+	select {
+	case <-h.done:
+		return nil
+	case <-ctx.Done():
+		return cerrors.New(ctx.Err(), "failed to send pending logs", nil)
+	}
+}
+
+func (h *BatchHook) run() {
+	defer close(h.done)
+
+	if closer, ok := h.sender.(io.Closer); ok {
+		defer func() { _ = closer.Close() }()
+	}
+
+	ticker := time.NewTicker(h.opts.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Record, 0, h.opts.BatchSize)
+
+	for {
+		select {
+		case r, ok := <-h.records:
+			if !ok {
+				h.send(batch)
+				return
+			}
+
+			batch = append(batch, r)
+			if len(batch) >= h.opts.BatchSize {
+				h.send(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			h.send(batch)
+			batch = batch[:0]
+		case ack := <-h.flushes:
+			batch = h.drain(batch)
+			h.send(batch)
+			batch = batch[:0]
+
+			close(ack)
+		}
+	}
+}
+
+// drain moves the queued records into the batch, sending full batches along the way.
+func (h *BatchHook) drain(batch []Record) []Record {
+	for {
+		select {
+		case r, ok := <-h.records:
+			if !ok {
+				return batch
+			}
+
+			batch = append(batch, r)
+			if len(batch) >= h.opts.BatchSize {
+				h.send(batch)
+				batch = batch[:0]
+			}
+		default:
+			return batch
+		}
+	}
+}
+
+func (h *BatchHook) send(batch []Record) {
+	if len(batch) == 0 {
+		return
+	}
+
+	backoff := h.opts.RetryBackoff
+
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), h.opts.SendTimeout)
+		err := h.sender.Send(ctx, batch)
+		cancel()
+
+		if err == nil {
+			h.addStats(BatchStats{Sent: len(batch)})
+			return
+		}
+
+		var partialErr partialError
+		if errors.As(err, &partialErr) && partialErr.sent > 0 && partialErr.sent < len(batch) {
+			h.addStats(BatchStats{Sent: partialErr.sent})
+			batch = batch[partialErr.sent:]
+		}
+
+		if attempt >= h.opts.MaxRetries || errors.As(err, &permanentError{}) {
+			h.addStats(BatchStats{Failed: len(batch)})
+			return
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (h *BatchHook) addStats(s BatchStats) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.stats.Sent += s.Sent
+	h.stats.Dropped += s.Dropped
+	h.stats.Failed += s.Failed
+}
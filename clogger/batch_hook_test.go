@@ -0,0 +1,333 @@
+package clogger_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gocopper/copper/cerrors"
+	"github.com/gocopper/copper/clogger"
+	"github.com/stretchr/testify/assert"
+)
+
+type testBatchServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	bodies   []string
+	statuses []int
+}
+
+// newTestBatchServer starts a server that records the request bodies and responds with the given status codes in
+// order, followed by 200 once they run out.
+func newTestBatchServer(t *testing.T, statuses ...int) *testBatchServer {
+	t.Helper()
+
+	s := &testBatchServer{statuses: statuses}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.bodies = append(s.bodies, string(body))
+
+		if len(s.statuses) > 0 {
+			w.WriteHeader(s.statuses[0])
+			s.statuses = s.statuses[1:]
+		}
+	}))
+
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *testBatchServer) Bodies() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.bodies
+}
+
+func TestBatchHook_HTTP(t *testing.T) {
+	t.Parallel()
+
+	var (
+		server = newTestBatchServer(t)
+		hook   = clogger.NewBatchHook(clogger.NewHTTPSender(server.URL, nil), clogger.BatchOptions{
+			BatchSize:     2,
+			FlushInterval: time.Hour,
+		})
+		logger = clogger.NewWithWriters(io.Discard, io.Discard, clogger.FormatPlain, nil, nil, []clogger.Hook{hook})
+	)
+
+	logger.WithTags(map[string]any{"user": "test-user"}).Info("test-msg-1")
+	logger.Error("test-msg-2", cerrors.New(nil, "test-err", nil))
+	logger.Info("test-msg-3")
+
+	assert.NoError(t, hook.Close(context.Background()))
+
+	bodies := server.Bodies()
+	assert.Len(t, bodies, 2)
+
+	lines := strings.Split(strings.TrimSpace(bodies[0]), "\n")
+	assert.Len(t, lines, 2)
+
+	var record map[string]any
+
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	assert.Equal(t, "test-msg-1", record["msg"])
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, map[string]any{"user": "test-user"}, record["tags"])
+
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &record))
+	assert.Equal(t, "test-msg-2", record["msg"])
+	assert.Contains(t, lines[1], "test-err")
+
+	assert.Contains(t, bodies[1], "test-msg-3")
+	assert.Equal(t, clogger.BatchStats{Sent: 3}, hook.Stats())
+}
+
+func TestBatchHook_Retry(t *testing.T) {
+	t.Parallel()
+
+	var (
+		server = newTestBatchServer(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+		hook   = clogger.NewBatchHook(clogger.NewHTTPSender(server.URL, nil), clogger.BatchOptions{
+			FlushInterval: time.Hour,
+			MaxRetries:    2,
+			RetryBackoff:  time.Millisecond,
+		})
+	)
+
+	hook.OnLog(clogger.LevelInfo, "test-msg", nil, nil)
+
+	assert.NoError(t, hook.Flush(context.Background()))
+	assert.Len(t, server.Bodies(), 3)
+	assert.Equal(t, clogger.BatchStats{Sent: 1}, hook.Stats())
+
+	assert.NoError(t, hook.Close(context.Background()))
+}
+
+func TestBatchHook_PermanentFailure(t *testing.T) {
+	t.Parallel()
+
+	var (
+		server = newTestBatchServer(t, http.StatusBadRequest)
+		hook   = clogger.NewBatchHook(clogger.NewHTTPSender(server.URL, nil), clogger.BatchOptions{
+			FlushInterval: time.Hour,
+			MaxRetries:    2,
+			RetryBackoff:  time.Millisecond,
+		})
+	)
+
+	hook.OnLog(clogger.LevelInfo, "test-msg", nil, nil)
+
+	assert.NoError(t, hook.Close(context.Background()))
+	assert.Len(t, server.Bodies(), 1)
+	assert.Equal(t, clogger.BatchStats{Failed: 1}, hook.Stats())
+}
+
+type blockingSender struct {
+	release chan struct{}
+}
+
+func (s *blockingSender) Send(context.Context, []clogger.Record) error {
+	<-s.release
+	return nil
+}
+
+func TestBatchHook_QueueFull(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender = &blockingSender{release: make(chan struct{})}
+		hook   = clogger.NewBatchHook(sender, clogger.BatchOptions{
+			QueueSize: 2,
+			BatchSize: 1,
+		})
+	)
+
+	// The first record is taken off the queue and blocks in Send while the next two fill the queue
+	hook.OnLog(clogger.LevelInfo, "test-msg", nil, nil)
+	assert.Eventually(t, func() bool {
+		hook.OnLog(clogger.LevelInfo, "test-msg", nil, nil)
+		return hook.Stats().Dropped > 0
+	}, time.Second, time.Millisecond)
+
+	close(sender.release)
+
+	assert.NoError(t, hook.Close(context.Background()))
+
+	stats := hook.Stats()
+	assert.Equal(t, 3, stats.Sent)
+	assert.Positive(t, stats.Dropped)
+}
+
+func TestBatchHook_OTLP(t *testing.T) {
+	t.Parallel()
+
+	var (
+		server = newTestBatchServer(t)
+		hook   = clogger.NewBatchHook(clogger.NewOTLPSender(server.URL, "test-svc", nil), clogger.BatchOptions{})
+		req    struct {
+			ResourceLogs []struct {
+				Resource struct {
+					Attributes []map[string]any `json:"attributes"`
+				} `json:"resource"`
+				ScopeLogs []struct {
+					LogRecords []map[string]any `json:"logRecords"`
+				} `json:"scopeLogs"`
+			} `json:"resourceLogs"`
+		}
+	)
+
+	hook.OnLog(clogger.LevelWarn, "test-msg", map[string]any{
+		"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736",
+		"req":      map[string]any{"status": 500},
+	}, errors.New("test-err")) //nolint:goerr113
+
+	assert.NoError(t, hook.Close(context.Background()))
+
+	bodies := server.Bodies()
+	assert.Len(t, bodies, 1)
+	assert.NoError(t, json.Unmarshal([]byte(bodies[0]), &req))
+
+	assert.Equal(t, []map[string]any{
+		{"key": "service.name", "value": map[string]any{"stringValue": "test-svc"}},
+	}, req.ResourceLogs[0].Resource.Attributes)
+
+	record := req.ResourceLogs[0].ScopeLogs[0].LogRecords[0]
+	assert.Equal(t, float64(13), record["severityNumber"])
+	assert.Equal(t, "WARN", record["severityText"])
+	assert.Equal(t, map[string]any{"stringValue": "test-msg"}, record["body"])
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", record["traceId"])
+	assert.Equal(t, []any{
+		map[string]any{"key": "req.status", "value": map[string]any{"intValue": "500"}},
+		map[string]any{"key": "trace_id", "value": map[string]any{"stringValue": "4bf92f3577b34da6a3ce929d0e0e4736"}},
+		map[string]any{"key": "exception.message", "value": map[string]any{"stringValue": "test-err"}},
+	}, record["attributes"])
+}
+
+func TestBatchHook_Syslog(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr     = filepath.Join(t.TempDir(), "syslog.sock")
+		messages = make(chan string, 10)
+	)
+
+	ln, err := net.Listen("unix", addr)
+	assert.NoError(t, err)
+
+	defer func() { _ = ln.Close() }()
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			messages <- scanner.Text()
+		}
+	}()
+
+	hook := clogger.NewBatchHook(clogger.NewSyslogSender("unix", addr, "test-app"), clogger.BatchOptions{})
+
+	hook.OnLog(clogger.LevelError, "test-msg", map[string]any{"user": "test user"}, errors.New("test-err")) //nolint:goerr113
+
+	assert.NoError(t, hook.Close(context.Background()))
+
+	msg := <-messages
+	assert.True(t, strings.HasPrefix(msg, "<11>"), msg)
+	assert.Contains(t, msg, " test-app[")
+	assert.True(t, strings.HasSuffix(msg, `]: test-msg error=test-err user="test user"`), msg)
+}
+
+func TestBatchHook_SyslogPartialFailure(t *testing.T) {
+	t.Parallel()
+
+	addr := filepath.Join(t.TempDir(), "syslog.sock")
+
+	conn, err := net.ListenPacket("unixgram", addr)
+	assert.NoError(t, err)
+
+	defer func() { _ = conn.Close() }()
+
+	hook := clogger.NewBatchHook(clogger.NewSyslogSender("unixgram", addr, "test-app"), clogger.BatchOptions{
+		FlushInterval: time.Hour,
+		MaxRetries:    2,
+		RetryBackoff:  time.Millisecond,
+	})
+
+	// The second message is too large to fit in a datagram, so the batch fails after the first one is written
+	hook.OnLog(clogger.LevelInfo, "test-msg-1", nil, nil)
+	hook.OnLog(clogger.LevelInfo, strings.Repeat("x", 1<<20), nil, nil)
+
+	assert.NoError(t, hook.Close(context.Background()))
+	assert.Equal(t, clogger.BatchStats{Sent: 1, Failed: 1}, hook.Stats())
+
+	var (
+		buf      = make([]byte, 1024)
+		messages []string
+	)
+
+	assert.NoError(t, conn.SetReadDeadline(time.Now().Add(100*time.Millisecond)))
+
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			break
+		}
+
+		messages = append(messages, string(buf[:n]))
+	}
+
+	assert.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "test-msg-1")
+}
+
+func TestNew_ShippingHooks(t *testing.T) {
+	t.Parallel()
+
+	server := newTestBatchServer(t)
+
+	logger, err := clogger.New(clogger.Config{
+		Out:            filepath.Join(t.TempDir(), "out.log"),
+		HTTPURL:        server.URL,
+		RedactFields:   []string{"password"},
+		RedactPatterns: []string{clogger.RedactPatternEmail},
+	}, nil)
+	assert.NoError(t, err)
+
+	logger.WithTags(map[string]any{"password": "test-password"}).Info("test-msg from test@example.com")
+
+	assert.NoError(t, logger.(io.Closer).Close())
+
+	bodies := server.Bodies()
+	assert.Len(t, bodies, 1)
+	assert.Contains(t, bodies[0], "test-msg from redacted")
+	assert.NotContains(t, bodies[0], "test-password")
+}
+
+func TestLoadConfig_InvalidSyslogNetwork(t *testing.T) {
+	t.Parallel()
+
+	_, err := clogger.LoadConfig(testConfigLoader{Syslog: true, SyslogNetwork: "http"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,90 @@
+package clogger_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/gocopper/copper/clogger"
+)
+
+func BenchmarkLogger_JSON(b *testing.B) {
+	for _, backend := range []string{clogger.BackendStd, clogger.BackendZap} {
+		b.Run(backend, func(b *testing.B) {
+			logger, err := clogger.New(clogger.Config{
+				Out:     os.DevNull,
+				Err:     os.DevNull,
+				Format:  clogger.FormatJSON,
+				Backend: backend,
+			}, nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			logger = logger.WithTags(map[string]any{
+				"user_id":    "test-user",
+				"request_id": "test-request",
+				"attempt":    3,
+			})
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				logger.Info("test-msg")
+			}
+		})
+	}
+}
+
+func BenchmarkLogger_JSONError(b *testing.B) {
+	testErr := errors.New("test-err") //nolint:goerr113
+
+	for _, backend := range []string{clogger.BackendStd, clogger.BackendZap} {
+		b.Run(backend, func(b *testing.B) {
+			logger, err := clogger.New(clogger.Config{
+				Out:     os.DevNull,
+				Err:     os.DevNull,
+				Format:  clogger.FormatJSON,
+				Backend: backend,
+			}, nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				logger.Error("test-msg", testErr)
+			}
+		})
+	}
+}
+
+func BenchmarkLogger_Plain(b *testing.B) {
+	for _, backend := range []string{clogger.BackendStd, clogger.BackendZap} {
+		b.Run(backend, func(b *testing.B) {
+			logger, err := clogger.New(clogger.Config{
+				Out:     os.DevNull,
+				Err:     os.DevNull,
+				Format:  clogger.FormatPlain,
+				Backend: backend,
+			}, nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			logger = logger.WithTags(map[string]any{
+				"user_id": "test-user",
+			})
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				logger.Info("test-msg")
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package clogger
+
+import (
+	"bytes"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gocopper/copper/cerrors"
+)
+
+// callerSkip is the number of frames between captureCaller and the code that wrote the log: captureCaller, the
+// logger's log method, and the Logger method (ex. Info) that called it.
+const callerSkip = 3
+
+// logCaller is the location of the code that wrote a log along with the stack that led to it.
+type logCaller struct {
+	file  string
+	line  int
+	stack []cerrors.StackFrame
+}
+
+// callerLogger is implemented by loggers that can write a log with the program counter of the code that wrote it
+// instead of the code that called the Logger method (ex. the slog.Record's PC when the log is written using slog).
+type callerLogger interface {
+	logWithCaller(lvl Level, msg string, err error, pc uintptr)
+}
+
+// captureCaller returns the location of the code that wrote the log and, if withStack is true, its stack. If pc is
+// set, it is used as the location instead of the code that called the Logger method, and the stack starts from it.
+// It returns nil if the location is not available.
+func captureCaller(withStack bool, pc uintptr) *logCaller {
+	var stack []uintptr
+
+	switch {
+	case withStack:
+		stack = cerrors.Callers(callerSkip)
+
+		if pc != 0 {
+			if i := slices.Index(stack, pc); i != -1 {
+				stack = stack[i:]
+			} else {
+				stack = []uintptr{pc}
+			}
+		}
+	case pc == 0:
+		var pcs [1]uintptr
+
+		n := runtime.Callers(callerSkip+1, pcs[:]) // +1 for runtime.Callers
+		stack = pcs[:n]
+	default:
+		stack = []uintptr{pc}
+	}
+
+	if len(stack) == 0 {
+		return nil
+	}
+
+	frame, _ := runtime.CallersFrames(stack[:1]).Next()
+	if frame.File == "" {
+		return nil
+	}
+
+	c := &logCaller{file: frame.File, line: frame.Line}
+
+	if withStack {
+		c.stack = cerrors.Frames(stack)
+	}
+
+	return c
+}
+
+// String returns the location as the file's dir and name along with the line (ex. "chttp/handler.go:42").
+func (c *logCaller) String() string {
+	dir, file := filepath.Split(c.file)
+
+	return filepath.Join(filepath.Base(dir), file) + ":" + strconv.Itoa(c.line)
+}
+
+// writeStack writes each frame of the stack on its own line with the given indent in the format used by Go's panics.
+func (c *logCaller) writeStack(buf *bytes.Buffer, indent string) {
+	for _, frame := range c.stack {
+		buf.WriteString(indent + frame.Function + "\n")
+		buf.WriteString(indent + "\t" + frame.File + ":" + strconv.Itoa(frame.Line) + "\n")
+	}
+}
+
+// stackString returns the stack as a single line where the frames are separated by semicolons.
+func (c *logCaller) stackString() string {
+	frames := make([]string, len(c.stack))
+	for i, frame := range c.stack {
+		frames[i] = frame.Function + " " + frame.File + ":" + strconv.Itoa(frame.Line)
+	}
+
+	return strings.Join(frames, "; ")
+}
@@ -0,0 +1,119 @@
+package clogger_test
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/gocopper/copper/clogger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_Caller(t *testing.T) {
+	t.Parallel()
+
+	formats := []clogger.Format{clogger.FormatPlain, clogger.FormatJSON, clogger.FormatLogfmt, clogger.FormatConsole}
+
+	for _, format := range formats {
+		format := format
+
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			out := filepath.Join(t.TempDir(), "out.log")
+
+			logger, err := clogger.New(clogger.Config{
+				Out:    out,
+				Format: format,
+				Caller: true,
+			}, nil)
+			assert.NoError(t, err)
+
+			_, _, line, _ := runtime.Caller(0)
+			logger.WithPrefix("test").WithTags(map[string]any{"key": "val"}).Info("test-msg")
+
+			assert.NoError(t, logger.(interface{ Close() error }).Close())
+
+			logs, err := os.ReadFile(out)
+			assert.NoError(t, err)
+			assert.Contains(t, string(logs), fmt.Sprintf("clogger/caller_test.go:%d", line+1))
+		})
+	}
+}
+
+func TestNewSlogHandler_Caller(t *testing.T) {
+	t.Parallel()
+
+	for _, backend := range []string{clogger.BackendStd, clogger.BackendZap} {
+		backend := backend
+
+		t.Run(backend, func(t *testing.T) {
+			t.Parallel()
+
+			out := filepath.Join(t.TempDir(), "out.log")
+
+			logger, err := clogger.New(clogger.Config{
+				Out:     out,
+				Backend: backend,
+				Caller:  true,
+			}, nil)
+			assert.NoError(t, err)
+
+			_, _, line, _ := runtime.Caller(0)
+			slog.New(clogger.NewSlogHandler(logger)).Info("test-msg", "key", "val")
+
+			assert.NoError(t, logger.(interface{ Close() error }).Close())
+
+			logs, err := os.ReadFile(out)
+			assert.NoError(t, err)
+			assert.Contains(t, string(logs), fmt.Sprintf("clogger/caller_test.go:%d", line+1))
+			assert.NotContains(t, string(logs), "slog.go")
+		})
+	}
+}
+
+func TestNew_ErrorStack(t *testing.T) {
+	t.Parallel()
+
+	for _, backend := range []string{clogger.BackendStd, clogger.BackendZap} {
+		backend := backend
+
+		t.Run(backend, func(t *testing.T) {
+			t.Parallel()
+
+			out := filepath.Join(t.TempDir(), "out.log")
+
+			logger, err := clogger.New(clogger.Config{
+				Out:        out,
+				Err:        out,
+				Format:     clogger.FormatJSON,
+				Backend:    backend,
+				ErrorStack: true,
+			}, nil)
+			assert.NoError(t, err)
+
+			logger.Info("test-info")
+			logger.Error("test-error", errors.New("test-err")) //nolint:goerr113
+
+			assert.NoError(t, logger.(interface{ Close() error }).Close())
+
+			logs := readJSONLogs(t, out)
+			assert.Len(t, logs, 2)
+
+			assert.NotContains(t, logs[0], "caller")
+			assert.NotContains(t, logs[0], "stack")
+
+			assert.True(t, strings.HasPrefix(logs[1]["caller"].(string), "clogger/caller_test.go:")) //nolint:forcetypeassert
+
+			stack, ok := logs[1]["stack"].([]any)
+			assert.True(t, ok)
+			assert.NotEmpty(t, stack)
+			assert.Contains(t, stack[0].(map[string]any)["function"], "TestNew_ErrorStack") //nolint:forcetypeassert
+		})
+	}
+}
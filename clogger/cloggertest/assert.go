@@ -0,0 +1,55 @@
+package cloggertest
+
+import "github.com/gocopper/copper/clogger"
+
+// AssertLogged fails the test if the logger, which must be a Recorder or a logger created from one, has no log with
+// the given level whose message contains msgSubstring and whose tags include all of the given tags.
+func AssertLogged(t TestingT, logger clogger.Logger, level clogger.Level, msgSubstring string,
+	tags ...map[string]any) bool {
+	t.Helper()
+
+	r, ok := recorderOf(t, logger)
+	if !ok {
+		return false
+	}
+
+	return r.AssertLogged(t, level, msgSubstring, tags...)
+}
+
+// AssertNotLogged fails the test if the logger, which must be a Recorder or a logger created from one, has any log
+// with the given level whose message contains msgSubstring and whose tags include all of the given tags.
+func AssertNotLogged(t TestingT, logger clogger.Logger, level clogger.Level, msgSubstring string,
+	tags ...map[string]any) bool {
+	t.Helper()
+
+	r, ok := recorderOf(t, logger)
+	if !ok {
+		return false
+	}
+
+	return r.AssertNotLogged(t, level, msgSubstring, tags...)
+}
+
+// AssertNoErrors fails the test if the logger, which must be a Recorder or a logger created from one, has any log at
+// the error level.
+func AssertNoErrors(t TestingT, logger clogger.Logger) bool {
+	t.Helper()
+
+	r, ok := recorderOf(t, logger)
+	if !ok {
+		return false
+	}
+
+	return r.AssertNoErrors(t)
+}
+
+func recorderOf(t TestingT, logger clogger.Logger) (*Recorder, bool) {
+	t.Helper()
+
+	r, ok := logger.(*Recorder)
+	if !ok {
+		t.Errorf("expected a logger created with cloggertest.NewRecorder, got %T", logger)
+	}
+
+	return r, ok
+}
@@ -0,0 +1,32 @@
+package cloggertest_test
+
+import (
+	"testing"
+
+	"github.com/gocopper/copper/clogger"
+	"github.com/gocopper/copper/clogger/cloggertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssertLogged(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ft     = &fakeT{}
+		logger = cloggertest.NewRecorder().WithTags(map[string]any{"user": "test-user"})
+	)
+
+	logger.Info("User logged in")
+
+	assert.True(t, cloggertest.AssertLogged(ft, logger, clogger.LevelInfo, "logged in", map[string]any{
+		"user": "test-user",
+	}))
+	assert.True(t, cloggertest.AssertNotLogged(ft, logger, clogger.LevelInfo, "logged out"))
+	assert.True(t, cloggertest.AssertNoErrors(ft, logger))
+	assert.Empty(t, ft.errs)
+
+	assert.False(t, cloggertest.AssertLogged(ft, logger, clogger.LevelWarn, "logged in"))
+	assert.False(t, cloggertest.AssertLogged(ft, clogger.NewNoop(), clogger.LevelInfo, "logged in"))
+	assert.Len(t, ft.errs, 2)
+	assert.Contains(t, ft.errs[1], "cloggertest.NewRecorder")
+}
@@ -0,0 +1,3 @@
+// Package cloggertest provides loggers that are useful when testing code that uses clogger. Recorder keeps the logs
+// so that they can be asserted on and NewLogger prints the logs through the test's t.Log.
+package cloggertest
@@ -0,0 +1,32 @@
+package cloggertest
+
+import (
+	"strings"
+
+	"github.com/gocopper/copper/clogger"
+)
+
+// TestLogger is the subset of testing.TB used by NewLogger.
+type TestLogger interface {
+	Helper()
+	Log(args ...any)
+}
+
+// NewLogger creates a clogger.Logger that prints the logs in the plain format through t.Log so that they are shown
+// along with the test that wrote them. The logger should not be used once the test has completed.
+func NewLogger(t TestLogger) clogger.Logger {
+	w := &testWriter{t: t}
+
+	return clogger.NewWithWriters(w, w, clogger.FormatPlain, nil, nil, nil)
+}
+
+type testWriter struct {
+	t TestLogger
+}
+
+func (w *testWriter) Write(p []byte) (int, error) {
+	w.t.Helper()
+	w.t.Log(strings.TrimSuffix(string(p), "\n"))
+
+	return len(p), nil
+}
@@ -0,0 +1,213 @@
+package cloggertest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gocopper/copper/clogger"
+)
+
+// TestingT is the subset of testing.TB used by the assertions.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// NewRecorder creates a Recorder that has no logs.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		logs: &recordedLogs{},
+		tags: make(map[string]any),
+	}
+}
+
+// Recorder is a clogger.Logger that keeps a record of each log. It is safe for concurrent use. Loggers created with
+// WithTags and WithPrefix share the record with the Recorder they were created from.
+type Recorder struct {
+	logs   *recordedLogs
+	tags   map[string]any
+	prefix string
+}
+
+type recordedLogs struct {
+	mu   sync.Mutex
+	logs []clogger.RecordedLog
+}
+
+func (r *Recorder) WithTags(tags map[string]any) clogger.Logger {
+	return &Recorder{
+		logs:   r.logs,
+		tags:   mergeTags(r.tags, tags),
+		prefix: r.prefix,
+	}
+}
+
+func (r *Recorder) WithPrefix(prefix string) clogger.Logger {
+	return &Recorder{
+		logs:   r.logs,
+		tags:   r.tags,
+		prefix: prefix,
+	}
+}
+
+func (r *Recorder) Debug(msg string) {
+	r.record(clogger.LevelDebug, msg, nil)
+}
+
+func (r *Recorder) Info(msg string) {
+	r.record(clogger.LevelInfo, msg, nil)
+}
+
+func (r *Recorder) Warn(msg string, err error) {
+	r.record(clogger.LevelWarn, msg, err)
+}
+
+func (r *Recorder) Error(msg string, err error) {
+	r.record(clogger.LevelError, msg, err)
+}
+
+// Logs returns a copy of the logs recorded so far.
+func (r *Recorder) Logs() []clogger.RecordedLog {
+	r.logs.mu.Lock()
+	defer r.logs.mu.Unlock()
+
+	return append([]clogger.RecordedLog(nil), r.logs.logs...)
+}
+
+// Reset removes the logs recorded so far.
+func (r *Recorder) Reset() {
+	r.logs.mu.Lock()
+	defer r.logs.mu.Unlock()
+
+	r.logs.logs = nil
+}
+
+// Find returns the logs with the given level whose message contains msgSubstring and whose tags include all of the
+// given tags.
+func (r *Recorder) Find(level clogger.Level, msgSubstring string, tags ...map[string]any) []clogger.RecordedLog {
+	var (
+		want    = mergeTags(tags...)
+		matches = make([]clogger.RecordedLog, 0)
+	)
+
+	for _, log := range r.Logs() {
+		if log.Level == level && strings.Contains(log.Msg, msgSubstring) && hasTags(log.Tags, want) {
+			matches = append(matches, log)
+		}
+	}
+
+	return matches
+}
+
+// AssertLogged fails the test if no log with the given level has a message that contains msgSubstring and tags
+// that include all of the given tags.
+func (r *Recorder) AssertLogged(t TestingT, level clogger.Level, msgSubstring string, tags ...map[string]any) bool {
+	t.Helper()
+
+	if len(r.Find(level, msgSubstring, tags...)) > 0 {
+		return true
+	}
+
+	t.Errorf("expected a %s log containing %q with tags %v, got:\n%s", level, msgSubstring, mergeTags(tags...),
+		formatLogs(r.Logs()))
+
+	return false
+}
+
+// AssertNotLogged fails the test if any log with the given level has a message that contains msgSubstring and tags
+// that include all of the given tags.
+func (r *Recorder) AssertNotLogged(t TestingT, level clogger.Level, msgSubstring string, tags ...map[string]any) bool {
+	t.Helper()
+
+	matches := r.Find(level, msgSubstring, tags...)
+	if len(matches) == 0 {
+		return true
+	}
+
+	t.Errorf("expected no %s log containing %q with tags %v, got:\n%s", level, msgSubstring, mergeTags(tags...),
+		formatLogs(matches))
+
+	return false
+}
+
+// AssertNoErrors fails the test if any log was recorded at the error level.
+func (r *Recorder) AssertNoErrors(t TestingT) bool {
+	t.Helper()
+
+	errs := make([]clogger.RecordedLog, 0)
+
+	for _, log := range r.Logs() {
+		if log.Level == clogger.LevelError {
+			errs = append(errs, log)
+		}
+	}
+
+	if len(errs) == 0 {
+		return true
+	}
+
+	t.Errorf("expected no error logs, got:\n%s", formatLogs(errs))
+
+	return false
+}
+
+func (r *Recorder) record(level clogger.Level, msg string, err error) {
+	r.logs.mu.Lock()
+	defer r.logs.mu.Unlock()
+
+	r.logs.logs = append(r.logs.logs, clogger.RecordedLog{
+		Level:  level,
+		Tags:   mergeTags(r.tags),
+		Msg:    msg,
+		Error:  err,
+		Prefix: r.prefix,
+	})
+}
+
+// hasTags returns true if tags has each of the wanted tags with an equal value.
+func hasTags(tags, want map[string]any) bool {
+	for k, v := range want {
+		got, ok := tags[k]
+		if !ok || !reflect.DeepEqual(got, v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func formatLogs(logs []clogger.RecordedLog) string {
+	if len(logs) == 0 {
+		return "\t(no logs)"
+	}
+
+	lines := make([]string, len(logs))
+
+	for i, log := range logs {
+		msg := log.Msg
+		if log.Prefix != "" {
+			msg = "[" + log.Prefix + "] " + msg
+		}
+
+		lines[i] = fmt.Sprintf("\t%s %s %v", log.Level, msg, log.Tags)
+		if log.Error != nil {
+			lines[i] += " error=" + log.Error.Error()
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func mergeTags(tags ...map[string]any) map[string]any {
+	merged := make(map[string]any)
+
+	for _, t := range tags {
+		for k, v := range t {
+			merged[k] = v
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,110 @@
+package cloggertest_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gocopper/copper/clogger"
+	"github.com/gocopper/copper/clogger/cloggertest"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeT struct {
+	errs []string
+	logs []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...any) {
+	t.errs = append(t.errs, fmt.Sprintf(format, args...))
+}
+
+func (t *fakeT) Log(args ...any) {
+	t.logs = append(t.logs, fmt.Sprint(args...))
+}
+
+func TestRecorder_AssertLogged(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ft       = &fakeT{}
+		recorder = cloggertest.NewRecorder()
+	)
+
+	recorder.
+		WithPrefix("auth").
+		WithTags(map[string]any{"user": "test-user", "attempt": 2}).
+		Warn("Failed to login", errors.New("test-err")) //nolint:goerr113
+
+	assert.True(t, recorder.AssertLogged(ft, clogger.LevelWarn, "login"))
+	assert.True(t, recorder.AssertLogged(ft, clogger.LevelWarn, "login", map[string]any{"user": "test-user"}))
+	assert.True(t, recorder.AssertNotLogged(ft, clogger.LevelError, "login"))
+	assert.Empty(t, ft.errs)
+
+	assert.False(t, recorder.AssertLogged(ft, clogger.LevelWarn, "login", map[string]any{"user": "other-user"}))
+	assert.False(t, recorder.AssertLogged(ft, clogger.LevelInfo, "login"))
+	assert.False(t, recorder.AssertNotLogged(ft, clogger.LevelWarn, "Failed"))
+	assert.Len(t, ft.errs, 3)
+	assert.Contains(t, ft.errs[0], "WARN [auth] Failed to login")
+	assert.Contains(t, ft.errs[0], "error=test-err")
+}
+
+func TestRecorder_AssertNoErrors(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ft       = &fakeT{}
+		recorder = cloggertest.NewRecorder()
+	)
+
+	recorder.Warn("test-warn", nil)
+	assert.True(t, recorder.AssertNoErrors(ft))
+
+	recorder.Error("test-error", nil)
+	assert.False(t, recorder.AssertNoErrors(ft))
+	assert.Len(t, ft.errs, 1)
+	assert.Contains(t, ft.errs[0], "test-error")
+	assert.NotContains(t, ft.errs[0], "test-warn")
+
+	recorder.Reset()
+	assert.Empty(t, recorder.Logs())
+}
+
+func TestRecorder_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	var (
+		recorder = cloggertest.NewRecorder()
+		wg       sync.WaitGroup
+	)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			recorder.WithTags(map[string]any{"i": i}).Info("test-msg")
+		}(i)
+	}
+
+	wg.Wait()
+
+	assert.Len(t, recorder.Logs(), 10)
+	assert.Len(t, recorder.Find(clogger.LevelInfo, "test-msg", map[string]any{"i": 5}), 1)
+}
+
+func TestNewLogger(t *testing.T) {
+	t.Parallel()
+
+	ft := &fakeT{}
+
+	cloggertest.NewLogger(ft).WithTags(map[string]any{"key": "val"}).Info("test-msg")
+
+	assert.Len(t, ft.logs, 1)
+	assert.True(t, strings.HasSuffix(ft.logs[0], "[INFO] test-msg where key=val"), ft.logs[0])
+}
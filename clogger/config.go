@@ -1,6 +1,8 @@
 package clogger
 
 import (
+	"time"
+
 	"github.com/gocopper/copper/cconfig"
 	"github.com/gocopper/copper/cerrors"
 )
@@ -10,8 +12,25 @@ type Format string
 
 // Formats supported by Logger
 const (
-	FormatPlain = Format("plain")
-	FormatJSON  = Format("json")
+	FormatPlain   = Format("plain")
+	FormatJSON    = Format("json")
+	FormatLogfmt  = Format("logfmt")
+	FormatConsole = Format("console")
+)
+
+func (f Format) valid() bool {
+	switch f {
+	case FormatPlain, FormatJSON, FormatLogfmt, FormatConsole:
+		return true
+	default:
+		return false
+	}
+}
+
+// Backends supported by New and NewCore
+const (
+	BackendStd = "std"
+	BackendZap = "zap"
 )
 
 // LoadConfig loads Config from app's config
@@ -23,10 +42,57 @@ func LoadConfig(appConfig cconfig.Loader) (Config, error) {
 		return Config{}, cerrors.New(err, "failed to load clogger config", nil)
 	}
 
-	if config.Format != FormatPlain && config.Format != FormatJSON {
+	if config.Format == "" {
 		config.Format = FormatPlain
 	}
 
+	if !config.Format.valid() {
+		return Config{}, cerrors.New(nil, "invalid format in clogger config", map[string]any{
+			"format": config.Format,
+		})
+	}
+
+	if config.Backend == BackendZap && config.Format == FormatLogfmt {
+		return Config{}, cerrors.New(nil, "logfmt format is not supported by the zap backend", nil)
+	}
+
+	if config.Backend != "" && config.Backend != BackendStd && config.Backend != BackendZap {
+		return Config{}, cerrors.New(nil, "invalid backend in clogger config", map[string]any{
+			"backend": config.Backend,
+		})
+	}
+
+	if config.AsyncOverflow != "" && config.AsyncOverflow != AsyncOverflowDrop &&
+		config.AsyncOverflow != AsyncOverflowBlock {
+		return Config{}, cerrors.New(nil, "invalid async_overflow in clogger config", map[string]any{
+			"async_overflow": config.AsyncOverflow,
+		})
+	}
+
+	if _, err := config.levelFilter(); err != nil {
+		return Config{}, cerrors.New(err, "invalid level_filter in clogger config", nil)
+	}
+
+	if _, err := newValueRedactor(config.RedactPatterns, config.RedactRegexes); err != nil {
+		return Config{}, cerrors.New(err, "invalid redaction in clogger config", nil)
+	}
+
+	if _, err := config.levels(); err != nil {
+		return Config{}, cerrors.New(err, "invalid level in clogger config", nil)
+	}
+
+	switch config.SyslogNetwork {
+	case "", "udp", "tcp", "unix", "unixgram":
+	default:
+		return Config{}, cerrors.New(nil, "invalid syslog_network in clogger config", map[string]any{
+			"syslog_network": config.SyslogNetwork,
+		})
+	}
+
+	if _, err := newSampler(config.Sampling); err != nil {
+		return Config{}, cerrors.New(err, "invalid sampling in clogger config", nil)
+	}
+
 	return config, nil
 }
 
@@ -37,4 +103,142 @@ type Config struct {
 	Format       Format   `toml:"format"`
 	RedactFields []string `toml:"redact_fields"`
 	LevelFilter  []string `toml:"level_filter"`
+
+	// Level is the minimum level of logs that are written (debug, info, warn, or error)
+	Level string `toml:"level" default:"debug"`
+
+	// PrefixLevels overrides Level for logs with the given prefixes (ex. csql = "warn")
+	PrefixLevels map[string]string `toml:"prefix_levels"`
+
+	// RotateMaxSizeMB rotates the Out and Err log files once they reach the given size
+	RotateMaxSizeMB uint `toml:"rotate_max_size_mb"`
+
+	// RotateMaxAgeHours rotates the Out and Err log files once they have been open for the given duration. The age is
+	// measured from when the process opened the files, so it starts again when the app restarts.
+	RotateMaxAgeHours uint `toml:"rotate_max_age_hours"`
+
+	// RotateMaxBackups is the number of rotated log files that are kept. If it is 0, all of them are kept.
+	RotateMaxBackups uint `toml:"rotate_max_backups"`
+
+	// RotateCompress gzips rotated log files
+	RotateCompress bool `toml:"rotate_compress"`
+
+	// ReopenOnSIGHUP reopens the log files when the process receives SIGHUP (ex. after logrotate moves them)
+	ReopenOnSIGHUP bool `toml:"reopen_on_sighup"`
+
+	// Async writes logs from a background goroutine using a buffer that holds up to AsyncBufferSize logs
+	Async           bool `toml:"async"`
+	AsyncBufferSize uint `toml:"async_buffer_size" default:"4096"`
+
+	// AsyncOverflow is the policy when the async buffer is full - "drop" drops new logs and "block" waits for space
+	AsyncOverflow string `toml:"async_overflow" default:"drop"`
+
+	// Backend is the implementation used to write logs - "std" (default) or "zap". The zap backend writes the plain
+	// and console formats with zap's tab-separated console layout.
+	Backend string `toml:"backend" default:"std"`
+
+	// RedactPatterns redacts values detected by the given built-in patterns (jwt, bearer, credit_card, email) in
+	// messages, tag values, and errors
+	RedactPatterns []string `toml:"redact_patterns"`
+
+	// RedactRegexes redacts values matched by the given regular expressions in messages, tag values, and errors
+	RedactRegexes []string `toml:"redact_regexes"`
+
+	// Caller annotates logs with the file and line of the code that wrote them
+	Caller bool `toml:"caller"`
+
+	// ErrorStack annotates error logs with the caller along with the stack that led to it
+	ErrorStack bool `toml:"error_stack"`
+
+	// Sampling limits how often identical messages are logged per level and prefix
+	Sampling []SamplingRule `toml:"sampling"`
+
+	// Syslog forwards logs to syslog. SyslogNetwork and SyslogAddr default to the local syslog socket (/dev/log) and
+	// SyslogTag defaults to the name of the executable.
+	Syslog        bool   `toml:"syslog"`
+	SyslogNetwork string `toml:"syslog_network"`
+	SyslogAddr    string `toml:"syslog_addr"`
+	SyslogTag     string `toml:"syslog_tag"`
+
+	// HTTPURL forwards logs to the given URL as batches of newline-delimited JSON
+	HTTPURL     string            `toml:"http_url"`
+	HTTPHeaders map[string]string `toml:"http_headers"`
+
+	// OTLPEndpoint forwards logs to an OpenTelemetry collector using OTLP/HTTP (ex. http://localhost:4318/v1/logs)
+	OTLPEndpoint    string            `toml:"otlp_endpoint"`
+	OTLPServiceName string            `toml:"otlp_service_name"`
+	OTLPHeaders     map[string]string `toml:"otlp_headers"`
+
+	// ShipQueueSize, ShipBatchSize, ShipFlushIntervalMS, and ShipMaxRetries configure how logs are forwarded to
+	// syslog, HTTPURL, and OTLPEndpoint
+	ShipQueueSize       uint `toml:"ship_queue_size" default:"1024"`
+	ShipBatchSize       uint `toml:"ship_batch_size" default:"100"`
+	ShipFlushIntervalMS uint `toml:"ship_flush_interval_ms" default:"1000"`
+	ShipMaxRetries      uint `toml:"ship_max_retries" default:"3"`
+
+	// SlogDefault installs the core logger as the default logger for the log/slog and log packages
+	SlogDefault bool `toml:"slog_default"`
+}
+
+func (c Config) levelFilter() (map[Level]bool, error) {
+	if len(c.LevelFilter) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	levelFilter := make(map[Level]bool, len(c.LevelFilter))
+	for _, name := range c.LevelFilter {
+		lvl, err := ParseLevel(name)
+		if err != nil {
+			return nil, err
+		}
+
+		levelFilter[lvl] = true
+	}
+
+	return levelFilter, nil
+}
+
+func (c Config) levels() (*Levels, error) {
+	min := LevelDebug
+	if c.Level != "" {
+		lvl, err := ParseLevel(c.Level)
+		if err != nil {
+			return nil, err
+		}
+
+		min = lvl
+	}
+
+	prefixes := make(map[string]Level, len(c.PrefixLevels))
+	for prefix, name := range c.PrefixLevels {
+		lvl, err := ParseLevel(name)
+		if err != nil {
+			return nil, cerrors.New(err, "invalid prefix level", map[string]any{
+				"prefix": prefix,
+			})
+		}
+
+		prefixes[prefix] = lvl
+	}
+
+	return NewLevels(min, prefixes), nil
+}
+
+func (c Config) rotateOptions() RotateOptions {
+	return RotateOptions{
+		MaxSize:        int64(c.RotateMaxSizeMB) * bytesInMB,
+		MaxAge:         time.Duration(c.RotateMaxAgeHours) * time.Hour,
+		MaxBackups:     int(c.RotateMaxBackups),
+		Compress:       c.RotateCompress,
+		ReopenOnSIGHUP: c.ReopenOnSIGHUP,
+	}
+}
+
+func (c Config) batchOptions() BatchOptions {
+	return BatchOptions{
+		QueueSize:     int(c.ShipQueueSize),
+		BatchSize:     int(c.ShipBatchSize),
+		FlushInterval: time.Duration(c.ShipFlushIntervalMS) * time.Millisecond,
+		MaxRetries:    int(c.ShipMaxRetries),
+	}
 }
@@ -0,0 +1,44 @@
+package clogger_test
+
+import (
+	"testing"
+
+	"github.com/gocopper/copper/clogger"
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfigLoader clogger.Config
+
+func (l testConfigLoader) Load(_ string, dest interface{}) error {
+	*dest.(*clogger.Config) = clogger.Config(l) //nolint:forcetypeassert
+
+	return nil
+}
+
+func TestLoadConfig_Format(t *testing.T) {
+	t.Parallel()
+
+	config, err := clogger.LoadConfig(testConfigLoader{})
+	assert.NoError(t, err)
+	assert.Equal(t, clogger.FormatPlain, config.Format)
+
+	config, err = clogger.LoadConfig(testConfigLoader{Format: clogger.FormatLogfmt})
+	assert.NoError(t, err)
+	assert.Equal(t, clogger.FormatLogfmt, config.Format)
+
+	_, err = clogger.LoadConfig(testConfigLoader{Format: "yaml"})
+	assert.Error(t, err)
+
+	_, err = clogger.LoadConfig(testConfigLoader{Format: clogger.FormatLogfmt, Backend: clogger.BackendZap})
+	assert.Error(t, err)
+}
+
+func TestLoadConfig_InvalidLevel(t *testing.T) {
+	t.Parallel()
+
+	_, err := clogger.LoadConfig(testConfigLoader{Level: "verbose"})
+	assert.Error(t, err)
+
+	_, err = clogger.LoadConfig(testConfigLoader{LevelFilter: []string{"info", "loud"}})
+	assert.Error(t, err)
+}
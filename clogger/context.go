@@ -0,0 +1,83 @@
+package clogger
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+)
+
+type ctxKey string
+
+const loggerCtxKey = ctxKey("clogger/logger")
+
+// ContextExtractor returns tags from the given context (ex. request id) that should be added to logs written using
+// a logger returned by FromContext.
+type ContextExtractor func(ctx context.Context) map[string]any
+
+var (
+	contextExtractorsMu sync.RWMutex                        //nolint:gochecknoglobals
+	contextExtractors   = make(map[string]ContextExtractor) //nolint:gochecknoglobals
+)
+
+// RegisterContextExtractor registers a ContextExtractor under the given name. Registering an extractor with a name that
+// is already registered replaces the existing one. Extractors are shared by all of the loggers in the process, so
+// they should be registered once (ex. in an init func) and only depend on the given context.
+func RegisterContextExtractor(name string, extractor ContextExtractor) {
+	contextExtractorsMu.Lock()
+	defer contextExtractorsMu.Unlock()
+
+	contextExtractors[name] = extractor
+}
+
+// UnregisterContextExtractor removes the ContextExtractor registered under the given name, if any.
+func UnregisterContextExtractor(name string) {
+	contextExtractorsMu.Lock()
+	defer contextExtractorsMu.Unlock()
+
+	delete(contextExtractors, name)
+}
+
+// WithContext returns a copy of ctx that holds the given logger. The logger can be retrieved using FromContext.
+func WithContext(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey, logger)
+}
+
+// FromContext returns the logger stored in ctx with WithContext along with the tags from all of the registered
+// ContextExtractors. If ctx does not hold a logger, the default slog logger is used instead.
+func FromContext(ctx context.Context) Logger {
+	logger, ok := ctx.Value(loggerCtxKey).(Logger)
+	if !ok {
+		logger = NewFromSlog(slog.Default().Handler())
+	}
+
+	tags := extractContextTags(ctx)
+	if len(tags) == 0 {
+		return logger
+	}
+
+	return logger.WithTags(tags)
+}
+
+// extractContextTags runs the registered extractors in the order of their names so that conflicting tags resolve
+// the same way every time.
+func extractContextTags(ctx context.Context) map[string]any {
+	contextExtractorsMu.RLock()
+	defer contextExtractorsMu.RUnlock()
+
+	names := make([]string, 0, len(contextExtractors))
+	for name := range contextExtractors {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	tags := make(map[string]any)
+	for _, name := range names {
+		for k, v := range contextExtractors[name](ctx) {
+			tags[k] = v
+		}
+	}
+
+	return tags
+}
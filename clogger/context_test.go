@@ -0,0 +1,85 @@
+package clogger_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/gocopper/copper/clogger"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCtxKey string
+
+func TestFromContext(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	clogger.RegisterContextExtractor("clogger_test", func(ctx context.Context) map[string]any {
+		userID, ok := ctx.Value(testCtxKey("user_id")).(string)
+		if !ok {
+			return nil
+		}
+
+		return map[string]any{"user_id": userID}
+	})
+	t.Cleanup(func() { clogger.UnregisterContextExtractor("clogger_test") })
+
+	ctx := clogger.WithContext(context.Background(), clogger.NewWithWriters(&buf, &buf, clogger.FormatPlain, nil, nil, nil))
+	ctx = context.WithValue(ctx, testCtxKey("user_id"), "test-user")
+
+	clogger.FromContext(ctx).Info("test-msg")
+
+	assert.Contains(t, buf.String(), "test-msg")
+	assert.Contains(t, buf.String(), "user_id=test-user")
+}
+
+func TestNewSlogHandler_ContextExtractors(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	clogger.RegisterContextExtractor("clogger_test_slog", func(ctx context.Context) map[string]any {
+		requestID, ok := ctx.Value(testCtxKey("request_id")).(string)
+		if !ok {
+			return nil
+		}
+
+		return map[string]any{"request_id": requestID}
+	})
+	t.Cleanup(func() { clogger.UnregisterContextExtractor("clogger_test_slog") })
+
+	logger := slog.New(clogger.NewSlogHandler(clogger.NewWithWriters(&buf, &buf, clogger.FormatPlain, nil, nil, nil)))
+	ctx := context.WithValue(context.Background(), testCtxKey("request_id"), "test-request")
+
+	logger.WithGroup("req").InfoContext(ctx, "test-msg", "method", "GET")
+
+	assert.Contains(t, buf.String(), "test-msg")
+	assert.Contains(t, buf.String(), "req=map[method:GET],request_id=test-request")
+}
+
+func TestUnregisterContextExtractor(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	clogger.RegisterContextExtractor("clogger_test_unregister", func(ctx context.Context) map[string]any {
+		return map[string]any{"unregistered": false}
+	})
+	clogger.UnregisterContextExtractor("clogger_test_unregister")
+
+	ctx := clogger.WithContext(context.Background(), clogger.NewWithWriters(&buf, &buf, clogger.FormatPlain, nil, nil, nil))
+
+	clogger.FromContext(ctx).Info("test-msg")
+
+	assert.Contains(t, buf.String(), "test-msg")
+	assert.NotContains(t, buf.String(), "unregistered")
+}
+
+func TestFromContext_NoLogger(t *testing.T) {
+	t.Parallel()
+
+	assert.NotNil(t, clogger.FromContext(context.Background()))
+}
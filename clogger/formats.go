@@ -0,0 +1,293 @@
+package clogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gocopper/copper/cerrors"
+)
+
+// ANSI color codes used by FormatConsole
+const (
+	colorReset  = "\033[0m"
+	colorGray   = "\033[90m"
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorBlue   = "\033[34m"
+	colorBold   = "\033[1m"
+)
+
+func (l *LoggerImpl) logLogfmt(dest io.Writer, lvl Level, msg string, err error, caller *logCaller) {
+	if l.prefix != "" {
+		msg = "[" + l.prefix + "] " + msg
+	}
+
+	tags := l.tags
+	if len(l.redactFields) > 0 {
+		tags = redactTags(tags, l.redactFields)
+	}
+
+	lb := getLineBuffer()
+	defer putLineBuffer(lb)
+
+	writeLogfmtPair(&lb.buf, "ts", time.Now().Format(time.RFC3339))
+	writeLogfmtPair(&lb.buf, "level", strings.ToLower(lvl.String()))
+	writeLogfmtPair(&lb.buf, "msg", msg)
+
+	if caller != nil {
+		writeLogfmtPair(&lb.buf, "caller", caller.String())
+	}
+
+	if err != nil {
+		messages := make([]string, 0)
+		for _, link := range cerrors.ChainOf(err) {
+			if link.Message != "" {
+				messages = append(messages, link.Message)
+			}
+		}
+
+		writeLogfmtPair(&lb.buf, "error", strings.Join(messages, ": "))
+
+		errTags := cerrors.Tags(err)
+		if len(l.redactFields) > 0 {
+			errTags = redactTags(errTags, l.redactFields)
+		}
+
+		for _, kv := range flattenTags("error", errTags) {
+			writeLogfmtPair(&lb.buf, kv.key, kv.val)
+		}
+	}
+
+	for _, kv := range flattenTags("", tags) {
+		writeLogfmtPair(&lb.buf, kv.key, kv.val)
+	}
+
+	if caller != nil && len(caller.stack) > 0 {
+		writeLogfmtPair(&lb.buf, "stack", caller.stackString())
+	}
+
+	lb.buf.WriteByte('\n')
+
+	_, _ = dest.Write(lb.buf.Bytes())
+}
+
+func (l *LoggerImpl) logConsole(dest io.Writer, lvl Level, msg string, err error, caller *logCaller) {
+	const (
+		consoleTimeFormat = "15:04:05.000"
+		indent            = "    "
+	)
+
+	tags := l.tags
+	if len(l.redactFields) > 0 {
+		tags = redactTags(tags, l.redactFields)
+	}
+
+	lb := getLineBuffer()
+	defer putLineBuffer(lb)
+
+	color := consoleColors()
+
+	lb.buf.WriteString(color(colorGray, time.Now().Format(consoleTimeFormat)))
+	lb.buf.WriteByte(' ')
+	lb.buf.WriteString(color(levelColor(lvl), fmt.Sprintf("%-5s", lvl.String())))
+	lb.buf.WriteByte(' ')
+
+	if l.prefix != "" {
+		lb.buf.WriteString(color(colorBlue, "["+l.prefix+"]"))
+		lb.buf.WriteByte(' ')
+	}
+
+	lb.buf.WriteString(color(colorBold, msg))
+
+	if caller != nil {
+		lb.buf.WriteByte(' ')
+		lb.buf.WriteString(color(colorGray, caller.String()))
+	}
+
+	lb.buf.WriteByte('\n')
+
+	// Align the values of the tags by padding their keys to the same width
+	kvs := flattenTags("", tags)
+
+	width := 0
+	for _, kv := range kvs {
+		width = max(width, len(kv.key))
+	}
+
+	for _, kv := range kvs {
+		lb.buf.WriteString(indent)
+		lb.buf.WriteString(color(colorGray, fmt.Sprintf("%-*s", width, kv.key)))
+		lb.buf.WriteString(" = ")
+		lb.buf.WriteString(kv.val)
+		lb.buf.WriteByte('\n')
+	}
+
+	if err != nil {
+		writeConsoleChain(&lb.buf, cerrors.ChainOf(err), indent, l.redactFields, color)
+	}
+
+	if caller != nil && len(caller.stack) > 0 {
+		lb.buf.WriteString(indent + color(colorGray, "stack:") + "\n")
+		caller.writeStack(&lb.buf, indent+"  ")
+	}
+
+	_, _ = dest.Write(lb.buf.Bytes())
+}
+
+// writeConsoleChain writes each link of the error chain on its own line, indenting each cause further than the
+// error it caused.
+func writeConsoleChain(buf *bytes.Buffer, chain cerrors.Chain, indent string, redactFields []string,
+	color func(code, s string) string) {
+	for i, link := range chain {
+		linkIndent := indent + strings.Repeat("  ", i)
+
+		label := "error: "
+		if i > 0 {
+			label = "└─ "
+		}
+
+		buf.WriteString(linkIndent + color(colorRed, label) + link.Message)
+
+		linkTags := link.Tags
+		if len(redactFields) > 0 {
+			linkTags = redactTags(linkTags, redactFields)
+		}
+
+		for j, kv := range flattenTags("", linkTags) {
+			sep := " "
+			if j == 0 {
+				sep = " " + color(colorGray, "where") + " "
+			}
+
+			buf.WriteString(sep + color(colorGray, kv.key+"=") + kv.val)
+		}
+
+		buf.WriteByte('\n')
+
+		for _, joined := range link.Joined {
+			writeConsoleChain(buf, joined, linkIndent+"  ", redactFields, color)
+		}
+	}
+}
+
+// consoleColors returns a func that wraps a string in the given ANSI color code, unless colors are disabled using
+// the NO_COLOR environment variable (https://no-color.org).
+func consoleColors() func(code, s string) string {
+	if os.Getenv("NO_COLOR") != "" {
+		return func(_, s string) string { return s }
+	}
+
+	return func(code, s string) string { return code + s + colorReset }
+}
+
+func levelColor(lvl Level) string {
+	switch lvl {
+	case LevelDebug:
+		return colorGray
+	case LevelInfo:
+		return colorGreen
+	case LevelWarn:
+		return colorYellow
+	case LevelError:
+		return colorRed
+	default:
+		return colorReset
+	}
+}
+
+type tagKV struct {
+	key string
+	val string
+}
+
+// flattenTags converts nested tags into sorted key-value pairs where the keys of nested tags are joined with dots
+// (ex. "req.method").
+func flattenTags(prefix string, tags map[string]any) []tagKV {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	kvs := make([]tagKV, 0, len(tags))
+	for _, k := range keys {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		if nested, ok := tags[k].(map[string]any); ok {
+			kvs = append(kvs, flattenTags(key, nested)...)
+			continue
+		}
+
+		kvs = append(kvs, tagKV{key: key, val: tagValueString(tags[k])})
+	}
+
+	return kvs
+}
+
+func tagValueString(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	case error:
+		return val.Error()
+	case fmt.Stringer:
+		return val.String()
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(val)
+	default:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprintf("%+v", val)
+		}
+
+		return string(b)
+	}
+}
+
+// writeLogfmtPair writes a key=value pair, quoting the value if needed.
+func writeLogfmtPair(buf *bytes.Buffer, key, val string) {
+	if buf.Len() > 0 {
+		buf.WriteByte(' ')
+	}
+
+	buf.WriteString(key)
+	buf.WriteByte('=')
+
+	if needsLogfmtQuotes(val) {
+		buf.WriteString(strconv.Quote(val))
+		return
+	}
+
+	buf.WriteString(val)
+}
+
+func needsLogfmtQuotes(s string) bool {
+	if s == "" {
+		return true
+	}
+
+	for _, r := range s {
+		if r == ' ' || r == '=' || r == '"' || r == '\\' || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,60 @@
+package clogger_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gocopper/copper/cerrors"
+	"github.com/gocopper/copper/clogger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogger_Logfmt(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := clogger.NewWithWriters(&buf, &buf, clogger.FormatLogfmt, []string{"password"}, nil, nil)
+
+	logger.WithTags(map[string]any{
+		"user":     "test user",
+		"password": "test-password",
+		"req":      map[string]any{"method": "GET"},
+	}).Error("test-msg", cerrors.New(errors.New("test-cause"), "test-err", map[string]any{ //nolint:goerr113
+		"id": 1,
+	}))
+
+	line := buf.String()
+
+	assert.True(t, strings.HasPrefix(line, "ts="))
+	assert.Contains(t, line, ` level=error msg=test-msg error="test-err: test-cause" error.id=1 `)
+	assert.Contains(t, line, ` password=redacted req.method=GET user="test user"`)
+	assert.True(t, strings.HasSuffix(line, "\n"))
+	assert.Equal(t, 1, strings.Count(line, "\n"))
+}
+
+func TestLogger_Console(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	var buf bytes.Buffer
+
+	logger := clogger.NewWithWriters(&buf, &buf, clogger.FormatConsole, nil, nil, nil)
+
+	logger.WithPrefix("csql").WithTags(map[string]any{
+		"id":         1,
+		"request_id": "test-request",
+	}).Error("test-msg", cerrors.New(errors.New("test-cause"), "test-err", map[string]any{ //nolint:goerr113
+		"query": "select 1",
+	}))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+
+	assert.Len(t, lines, 5)
+	assert.True(t, strings.HasSuffix(lines[0], "ERROR [csql] test-msg"))
+	assert.Equal(t, "    id         = 1", lines[1])
+	assert.Equal(t, "    request_id = test-request", lines[2])
+	assert.Equal(t, "    error: test-err where query=select 1", lines[3])
+	assert.Equal(t, "      └─ test-cause", lines[4])
+}
@@ -1,5 +1,70 @@
 package clogger
 
+import (
+	"github.com/gocopper/copper/cerrors"
+)
+
 type Hook interface {
 	OnLog(level Level, msg string, tags map[string]any, err error)
 }
+
+// redactingHook passes the logs to the wrapped hook after redacting them
+type redactingHook struct {
+	hook   Hook
+	redact func(r Record) Record
+}
+
+func (h redactingHook) OnLog(level Level, msg string, tags map[string]any, err error) {
+	r := h.redact(Record{Level: level, Msg: msg, Tags: tags, Err: err})
+
+	h.hook.OnLog(level, r.Msg, r.Tags, r.Err)
+}
+
+// redactHooks wraps the hooks so that they are given the logs with the redacted fields and the sensitive values
+// redacted. The hooks are returned as they are if there is nothing to redact.
+func redactHooks(hooks []Hook, redactFields []string, valueRedactor *valueRedactor) []Hook {
+	if len(hooks) == 0 || (len(redactFields) == 0 && valueRedactor == nil) {
+		return hooks
+	}
+
+	redact := recordRedactor(redactFields, valueRedactor)
+
+	redacted := make([]Hook, len(hooks))
+	for i := range hooks {
+		redacted[i] = redactingHook{hook: hooks[i], redact: redact}
+	}
+
+	return redacted
+}
+
+// NewRecordRedactor returns a func that redacts a record the same way a logger created with the given config redacts
+// its output. It can be used to redact errors that are reported without being logged.
+func NewRecordRedactor(config Config) (func(r Record) Record, error) {
+	valueRedactor, err := newValueRedactor(config.RedactPatterns, config.RedactRegexes)
+	if err != nil {
+		return nil, err
+	}
+
+	return recordRedactor(expandRedactedFields(config.RedactFields), valueRedactor), nil
+}
+
+// recordRedactor returns a func that redacts a record the same way the logger redacts its output. The tags and
+// messages of the error are redacted in place so that its chain (ex. kinds and stacks) is kept.
+func recordRedactor(redactFields []string, valueRedactor *valueRedactor) func(r Record) Record {
+	return func(r Record) Record {
+		r.Msg = valueRedactor.String(r.Msg)
+		r.Tags = valueRedactor.Tags(redactTags(r.Tags, redactFields))
+
+		if r.Err != nil {
+			r.Err = cerrors.MapTags(r.Err, func(errTags map[string]any) map[string]any {
+				return valueRedactor.Tags(redactTags(errTags, redactFields))
+			})
+		}
+
+		if r.Err != nil && valueRedactor != nil {
+			r.Err = cerrors.MapMessages(r.Err, valueRedactor.String)
+		}
+
+		return r
+	}
+}
@@ -0,0 +1,107 @@
+package clogger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gocopper/copper/cerrors"
+)
+
+// NewHTTPSender creates a BatchSender that posts each batch of records to the given URL as newline-delimited JSON.
+// The headers are added to every request (ex. for authorization).
+func NewHTTPSender(url string, headers map[string]string) *HTTPSender {
+	return &HTTPSender{
+		url:     url,
+		headers: headers,
+		client:  &http.Client{},
+	}
+}
+
+// HTTPSender is a BatchSender that sends records to a generic HTTP endpoint as NDJSON.
+type HTTPSender struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+type httpRecord struct {
+	Time  string         `json:"ts"`
+	Level string         `json:"level"`
+	Msg   string         `json:"msg"`
+	Tags  map[string]any `json:"tags,omitempty"`
+	Error any            `json:"error,omitempty"`
+}
+
+// Send posts the records as one JSON object per line. Requests that fail with a 5xx or 429 status code are retried
+// by BatchHook.
+func (s *HTTPSender) Send(ctx context.Context, records []Record) error {
+	var body bytes.Buffer
+
+	enc := json.NewEncoder(&body)
+	enc.SetEscapeHTML(false)
+
+	for _, r := range records {
+		hr := httpRecord{
+			Time:  r.Time.Format(time.RFC3339Nano),
+			Level: r.Level.String(),
+			Msg:   r.Msg,
+			Tags:  r.Tags,
+		}
+
+		if r.Err != nil {
+			hr.Error = cerrors.ChainOf(r.Err)
+		}
+
+		err := enc.Encode(hr)
+		if err != nil {
+			return permanentError{err: cerrors.New(err, "failed to encode log record", nil)}
+		}
+	}
+
+	return postBatch(ctx, s.client, s.url, "application/x-ndjson", s.headers, &body)
+}
+
+// postBatch posts the body to the given URL. Responses that will not succeed when retried are returned as a
+// permanentError.
+func postBatch(ctx context.Context, client *http.Client, url, contentType string, headers map[string]string,
+	body io.Reader) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return permanentError{err: cerrors.New(err, "failed to create request", nil)}
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return cerrors.New(err, "failed to send request", map[string]any{
+			"url": url,
+		})
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+
+	err = cerrors.New(nil, "server responded with a non-2xx status code", map[string]any{
+		"url":         url,
+		"status_code": resp.StatusCode,
+	})
+
+	if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+		return err
+	}
+
+	return permanentError{err: err}
+}
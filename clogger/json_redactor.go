@@ -4,31 +4,62 @@ import (
 	"bytes"
 	"encoding/json"
 	"strings"
+
+	"github.com/gocopper/copper/cerrors"
 )
 
+// redactedValue replaces both the values of redacted fields and the sensitive values found by the value redactor
+const redactedValue = "redacted"
+
 func redactJSONObject(in map[string]any, redactFields []string) (map[string]any, error) {
-	var b bytes.Buffer
+	redacted, err := redactJSONValue(in, redactFields)
+	if err != nil {
+		return nil, err
+	}
 
-	enc := json.NewEncoder(&b)
-	enc.SetEscapeHTML(false)
+	var out map[string]any
 
-	err := enc.Encode(in)
+	// Use json.Number to keep the original formatting of numbers (ex. large ints are not converted to floats)
+	dec := json.NewDecoder(bytes.NewReader(redacted))
+	dec.UseNumber()
+
+	err = dec.Decode(&out)
 	if err != nil {
 		return nil, err
 	}
 
-	redacted, err := redactJSON(b.Bytes(), redactFields)
+	return out, nil
+}
+
+// redactTags redacts the given tags. If the tags cannot be redacted, they are replaced entirely to avoid leaking
+// sensitive values.
+func redactTags(tags map[string]any, redactFields []string) map[string]any {
+	if len(tags) == 0 {
+		return tags
+	}
+
+	redacted, err := redactJSONObject(tags, redactFields)
 	if err != nil {
-		return nil, err
+		return map[string]any{
+			"tags": cerrors.New(err, "tag redaction failed", nil).Error(),
+		}
 	}
 
-	var out map[string]any
-	err = json.Unmarshal(redacted, &out)
+	return redacted
+}
+
+func redactJSONValue(in any, redactFields []string) (json.RawMessage, error) {
+	var b bytes.Buffer
+
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+
+	err := enc.Encode(in)
 	if err != nil {
 		return nil, err
 	}
 
-	return out, nil
+	return redactJSON(b.Bytes(), redactFields)
 }
 
 func redactJSON(in json.RawMessage, redactFields []string) (json.RawMessage, error) {
@@ -46,7 +77,7 @@ func redactJSON(in json.RawMessage, redactFields []string) (json.RawMessage, err
 			didRedact := false
 			for i := range redactFields {
 				if strings.Contains(strings.ToLower(k), strings.ToLower(redactFields[i])) {
-					cont[k] = json.RawMessage(`"redacted"`)
+					cont[k] = json.RawMessage(`"` + redactedValue + `"`)
 					didRedact = true
 					break
 				}
@@ -1,6 +1,10 @@
 package clogger
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/gocopper/copper/cerrors"
+)
 
 // Level represents the severity level of a log.
 type Level int
@@ -28,18 +32,37 @@ func (l Level) String() string {
 	}
 }
 
-// ParseLevel parses a log level from a string.
-func ParseLevel(s string) Level {
-	switch strings.ToLower(s) {
+// MarshalText encodes the level as its lowercase name (ex. "warn") so that it can be parsed by ParseLevel.
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(strings.ToLower(l.String())), nil
+}
+
+// UnmarshalText decodes a level name using ParseLevel.
+func (l *Level) UnmarshalText(text []byte) error {
+	lvl, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+
+	*l = lvl
+
+	return nil
+}
+
+// ParseLevel parses a log level from its case-insensitive name (debug, info, warn, or error).
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
 	case "debug":
-		return LevelDebug
+		return LevelDebug, nil
 	case "info":
-		return LevelInfo
-	case "warn":
-		return LevelWarn
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
 	case "error":
-		return LevelError
+		return LevelError, nil
 	default:
-		return LevelDebug
+		return 0, cerrors.New(nil, "unknown log level", map[string]any{
+			"level": s,
+		})
 	}
 }
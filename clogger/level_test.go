@@ -16,3 +16,14 @@ func TestLevel_String(t *testing.T) {
 	assert.Equal(t, "ERROR", clogger.LevelError.String())
 	assert.Equal(t, "UNKNOWN", clogger.Level(-99).String())
 }
+
+func TestParseLevel(t *testing.T) {
+	t.Parallel()
+
+	lvl, err := clogger.ParseLevel("WARN")
+	assert.NoError(t, err)
+	assert.Equal(t, clogger.LevelWarn, lvl)
+
+	_, err = clogger.ParseLevel("verbose")
+	assert.Error(t, err)
+}
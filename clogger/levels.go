@@ -0,0 +1,137 @@
+package clogger
+
+import (
+	"strings"
+	"sync"
+)
+
+// Levels holds the minimum level of logs that are written, along with overrides for specific prefixes (ex. "csql").
+// It is safe to change levels while logs are being written, so an app can adjust them without restarting.
+//
+// A prefix override applies to loggers created with WithPrefix and to messages that start with "[prefix]". Prefixes
+// are hierarchical - an override for "csql" also applies to "csql/migrator" and "csql.migrator" unless they have
+// their own override.
+type Levels struct {
+	mu       sync.RWMutex
+	min      Level
+	prefixes map[string]Level
+}
+
+// NewLevels creates Levels with the given minimum level and per-prefix overrides.
+func NewLevels(min Level, prefixes map[string]Level) *Levels {
+	l := &Levels{
+		min:      min,
+		prefixes: make(map[string]Level, len(prefixes)),
+	}
+
+	for prefix, lvl := range prefixes {
+		l.prefixes[trimPrefix(prefix)] = lvl
+	}
+
+	return l
+}
+
+// Min returns the minimum level of logs without a prefix override.
+func (l *Levels) Min() Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.min
+}
+
+// SetMin sets the minimum level of logs without a prefix override.
+func (l *Levels) SetMin(lvl Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.min = lvl
+}
+
+// Prefixes returns a copy of the per-prefix overrides.
+func (l *Levels) Prefixes() map[string]Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	prefixes := make(map[string]Level, len(l.prefixes))
+	for prefix, lvl := range l.prefixes {
+		prefixes[prefix] = lvl
+	}
+
+	return prefixes
+}
+
+// SetPrefix sets the minimum level of logs with the given prefix. The prefix may be given with or without its
+// brackets (ex. "[csql]" or "csql").
+func (l *Levels) SetPrefix(prefix string, lvl Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.prefixes[trimPrefix(prefix)] = lvl
+}
+
+// ResetPrefix removes the override for the given prefix so that its logs use the minimum level again.
+func (l *Levels) ResetPrefix(prefix string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.prefixes, trimPrefix(prefix))
+}
+
+// Enabled returns true if a log with the given prefix and level should be written.
+func (l *Levels) Enabled(prefix string, lvl Level) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	min := l.min
+
+	if prefix != "" && len(l.prefixes) > 0 {
+		var matched string
+
+		for p, pLvl := range l.prefixes {
+			if len(p) > len(matched) && hasPrefixSegment(prefix, p) {
+				matched, min = p, pLvl
+			}
+		}
+	}
+
+	return lvl >= min
+}
+
+// LevelsOf returns the Levels used by the given logger, if it supports them.
+func LevelsOf(logger Logger) (*Levels, bool) {
+	l, ok := logger.(interface{ Levels() *Levels })
+	if !ok || l.Levels() == nil {
+		return nil, false
+	}
+
+	return l.Levels(), true
+}
+
+// hasPrefixSegment returns true if prefix is the same as p or if p is one of its parents (ex. "csql" for
+// "csql/migrator").
+func hasPrefixSegment(prefix, p string) bool {
+	if !strings.HasPrefix(prefix, p) {
+		return false
+	}
+
+	return len(prefix) == len(p) || prefix[len(p)] == '/' || prefix[len(p)] == '.'
+}
+
+// trimPrefix returns the prefix without the brackets used in log messages (ex. "csql" for "[csql]").
+func trimPrefix(prefix string) string {
+	return strings.Trim(prefix, "[]")
+}
+
+// msgPrefix returns the prefix of a log message formatted as "[prefix] msg".
+func msgPrefix(msg string) string {
+	if !strings.HasPrefix(msg, "[") {
+		return ""
+	}
+
+	end := strings.IndexByte(msg, ']')
+	if end < 0 {
+		return ""
+	}
+
+	return msg[1:end]
+}
@@ -0,0 +1,134 @@
+package clogger_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gocopper/copper/clogger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevels_Enabled(t *testing.T) {
+	t.Parallel()
+
+	levels := clogger.NewLevels(clogger.LevelInfo, map[string]clogger.Level{
+		"csql":          clogger.LevelWarn,
+		"csql/migrator": clogger.LevelDebug,
+	})
+
+	assert.False(t, levels.Enabled("", clogger.LevelDebug))
+	assert.True(t, levels.Enabled("", clogger.LevelInfo))
+	assert.False(t, levels.Enabled("csql", clogger.LevelInfo))
+	assert.False(t, levels.Enabled("csql.querier", clogger.LevelInfo))
+	assert.True(t, levels.Enabled("csql/migrator", clogger.LevelDebug))
+	assert.True(t, levels.Enabled("csqlx", clogger.LevelInfo))
+
+	levels.ResetPrefix("csql")
+	levels.SetMin(clogger.LevelError)
+
+	assert.False(t, levels.Enabled("csql", clogger.LevelWarn))
+	assert.True(t, levels.Enabled("csql", clogger.LevelError))
+}
+
+func TestNew_Levels(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger, err := clogger.New(clogger.Config{
+		Level:        "info",
+		PrefixLevels: map[string]string{"csql": "warn"},
+	}, nil)
+	assert.NoError(t, err)
+
+	levels, ok := clogger.LevelsOf(logger)
+	assert.True(t, ok)
+	assert.Equal(t, clogger.LevelInfo, levels.Min())
+	assert.Equal(t, map[string]clogger.Level{"csql": clogger.LevelWarn}, levels.Prefixes())
+
+	_, ok = clogger.LevelsOf(clogger.NewWithWriters(&buf, &buf, clogger.FormatPlain, nil, nil, nil))
+	assert.False(t, ok)
+}
+
+func TestNew_InvalidLevel(t *testing.T) {
+	t.Parallel()
+
+	_, err := clogger.New(clogger.Config{Level: "verbose"}, nil)
+	assert.Error(t, err)
+
+	_, err = clogger.New(clogger.Config{PrefixLevels: map[string]string{"csql": "loud"}}, nil)
+	assert.Error(t, err)
+}
+
+func TestLogger_PrefixLevels(t *testing.T) {
+	t.Parallel()
+
+	out := filepath.Join(t.TempDir(), "out.log")
+
+	logger, err := clogger.New(clogger.Config{
+		Out:          out,
+		Err:          out,
+		Level:        "info",
+		PrefixLevels: map[string]string{"[csql]": "warn"},
+	}, nil)
+	assert.NoError(t, err)
+
+	levels, _ := clogger.LevelsOf(logger)
+
+	logger.Info("[csql] test-csql-info")
+	logger.WithPrefix("csql").Warn("test-csql-warn", nil)
+	logger.Info("[chttp] test-chttp-info")
+	logger.Debug("test-debug")
+
+	levels.SetPrefix("chttp", clogger.LevelError)
+	logger.Info("[chttp] test-chttp-info-after")
+
+	logs, err := os.ReadFile(out)
+	assert.NoError(t, err)
+
+	assert.NotContains(t, string(logs), "test-csql-info")
+	assert.Contains(t, string(logs), "test-csql-warn")
+	assert.Contains(t, string(logs), "test-chttp-info")
+	assert.NotContains(t, string(logs), "test-debug")
+	assert.NotContains(t, string(logs), "test-chttp-info-after")
+}
+
+func TestLevels_BracketedPrefix(t *testing.T) {
+	t.Parallel()
+
+	levels := clogger.NewLevels(clogger.LevelInfo, map[string]clogger.Level{"[csql]": clogger.LevelWarn})
+	assert.False(t, levels.Enabled("csql", clogger.LevelInfo))
+
+	levels.SetPrefix("[chttp]", clogger.LevelError)
+	assert.Equal(t, map[string]clogger.Level{"csql": clogger.LevelWarn, "chttp": clogger.LevelError}, levels.Prefixes())
+
+	levels.ResetPrefix("[csql]")
+	assert.True(t, levels.Enabled("csql", clogger.LevelInfo))
+}
+
+func TestNewWithLevels(t *testing.T) {
+	t.Parallel()
+
+	core, err := clogger.New(clogger.Config{Level: "info"}, nil)
+	assert.NoError(t, err)
+
+	coreLevels, _ := clogger.LevelsOf(core)
+
+	logger, err := clogger.NewWithLevels(clogger.Config{Level: "debug"}, coreLevels, nil)
+	assert.NoError(t, err)
+
+	levels, ok := clogger.LevelsOf(logger)
+	assert.True(t, ok)
+	assert.Same(t, coreLevels, levels)
+
+	levels.SetMin(clogger.LevelError)
+	assert.Equal(t, clogger.LevelError, coreLevels.Min())
+
+	logger, err = clogger.NewWithLevels(clogger.Config{Backend: clogger.BackendZap}, coreLevels, nil)
+	assert.NoError(t, err)
+
+	levels, _ = clogger.LevelsOf(logger)
+	assert.Same(t, coreLevels, levels)
+}
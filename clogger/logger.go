@@ -1,11 +1,7 @@
 package clogger
 
 import (
-	"encoding/json"
 	"io"
-	"log"
-	"os"
-	"strings"
 	"time"
 
 	"github.com/gocopper/copper/cerrors"
@@ -31,172 +27,276 @@ func NewWithWriters(out, errWriter io.Writer, format Format, redactFields []stri
 		format:       format,
 		redactFields: expandRedactedFields(redactFields),
 		levelFilter:  levelFilter,
-		hooks:        hooks,
+		hooks:        redactHooks(hooks, expandRedactedFields(redactFields), nil),
 	}
 }
 
 func NewCore(config Config) (CoreLogger, error) {
-	const LogFilePerms = 0666
+	logger, err := newLogger(config, nil, make([]Hook, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	if config.SlogDefault {
+		SetSlogDefault(logger)
+	}
 
+	return logger, nil
+}
+
+func New(config Config, hooks []Hook) (Logger, error) {
+	return newLogger(config, nil, hooks)
+}
+
+// NewWithLevels creates a Logger like New that uses the given levels instead of the ones in Config. Loggers that
+// share levels (ex. an app's core logger and its other loggers) are all updated when the levels are changed.
+func NewWithLevels(config Config, levels *Levels, hooks []Hook) (Logger, error) {
+	return newLogger(config, levels, hooks)
+}
+
+func newLogger(config Config, levels *Levels, hooks []Hook) (Logger, error) {
 	var (
-		outFile io.Writer = os.Stdout
-		errFile io.Writer = os.Stderr
-		err     error
+		logger Logger
+		err    error
 	)
 
-	if config.Out != "" {
-		outFile, err = os.OpenFile(config.Out, os.O_APPEND|os.O_CREATE|os.O_WRONLY, LogFilePerms)
-		if err != nil {
-			return nil, cerrors.New(err, "failed to open log file", map[string]any{
-				"path": config.Out,
-			})
-		}
+	if config.Backend != "" && config.Backend != BackendStd && config.Backend != BackendZap {
+		return nil, cerrors.New(nil, "invalid backend", map[string]any{
+			"backend": config.Backend,
+		})
 	}
 
-	if config.Out == config.Err {
-		errFile = outFile
-	} else if config.Err != "" {
-		errFile, err = os.OpenFile(config.Err, os.O_APPEND|os.O_CREATE|os.O_WRONLY, LogFilePerms)
+	if levels == nil {
+		levels, err = config.levels()
 		if err != nil {
-			return nil, cerrors.New(err, "failed to open error log file", map[string]any{
-				"path": config.Err,
-			})
+			return nil, cerrors.New(err, "invalid log level", nil)
 		}
 	}
 
-	var levelFilter map[Level]bool
-	if len(config.LevelFilter) > 0 {
-		levelFilter = make(map[Level]bool)
-		for _, lvl := range config.LevelFilter {
-			levelFilter[ParseLevel(lvl)] = true
-		}
+	if config.Backend == BackendZap {
+		logger, err = newZapLogger(config, levels, hooks)
+	} else {
+		logger, err = newLoggerImpl(config, levels, hooks)
 	}
 
-	return &LoggerImpl{
-		out:          outFile,
-		err:          errFile,
-		tags:         make(map[string]any),
-		format:       config.Format,
-		redactFields: expandRedactedFields(config.RedactFields),
-		levelFilter:  levelFilter,
-		hooks:        make([]Hook, 0),
-	}, nil
-}
+	if err != nil {
+		return nil, err
+	}
 
-func New(config Config, hooks []Hook) (Logger, error) {
-	const LogFilePerms = 0666
+	return logger, nil
+}
 
-	var (
-		outFile io.Writer = os.Stdout
-		errFile io.Writer = os.Stderr
-		err     error
-	)
+func newLoggerImpl(config Config, levels *Levels, hooks []Hook) (*LoggerImpl, error) {
+	levelFilter, err := config.levelFilter()
+	if err != nil {
+		return nil, cerrors.New(err, "invalid level filter", nil)
+	}
 
-	if config.Out != "" {
-		outFile, err = os.OpenFile(config.Out, os.O_APPEND|os.O_CREATE|os.O_WRONLY, LogFilePerms)
-		if err != nil {
-			return nil, cerrors.New(err, "failed to open log file", map[string]any{
-				"path": config.Out,
-			})
-		}
+	valueRedactor, err := newValueRedactor(config.RedactPatterns, config.RedactRegexes)
+	if err != nil {
+		return nil, cerrors.New(err, "invalid value redaction", nil)
 	}
 
-	if config.Out == config.Err {
-		errFile = outFile
-	} else if config.Err != "" {
-		errFile, err = os.OpenFile(config.Err, os.O_APPEND|os.O_CREATE|os.O_WRONLY, LogFilePerms)
-		if err != nil {
-			return nil, cerrors.New(err, "failed to open error log file", map[string]any{
-				"path": config.Err,
-			})
-		}
+	sampler, err := newSampler(config.Sampling)
+	if err != nil {
+		return nil, cerrors.New(err, "invalid sampling", nil)
 	}
 
-	var levelFilter map[Level]bool
-	if len(config.LevelFilter) > 0 {
-		levelFilter = make(map[Level]bool)
-		for _, lvl := range config.LevelFilter {
-			levelFilter[ParseLevel(lvl)] = true
-		}
+	o, err := openOutputs(config)
+	if err != nil {
+		return nil, err
 	}
 
 	return &LoggerImpl{
-		out:          outFile,
-		err:          errFile,
-		tags:         make(map[string]any),
-		format:       config.Format,
-		redactFields: expandRedactedFields(config.RedactFields),
-		levelFilter:  levelFilter,
-		hooks:        hooks,
+		out:           o.out,
+		err:           o.err,
+		tags:          make(map[string]any),
+		format:        config.Format,
+		redactFields:  expandRedactedFields(config.RedactFields),
+		valueRedactor: valueRedactor,
+		levelFilter:   levelFilter,
+		levels:        levels,
+		caller:        config.Caller,
+		errorStack:    config.ErrorStack,
+		sampler:       sampler,
+		hooks:         o.withHooks(hooks, expandRedactedFields(config.RedactFields), valueRedactor),
+		outputs:       o,
 	}, nil
 }
 
 type LoggerImpl struct {
-	out          io.Writer
-	err          io.Writer
-	tags         map[string]any
-	format       Format
-	redactFields []string
-	prefix       string
-	levelFilter  map[Level]bool
-	hooks        []Hook
+	out           io.Writer
+	err           io.Writer
+	tags          map[string]any
+	format        Format
+	redactFields  []string
+	valueRedactor *valueRedactor
+	prefix        string
+	levelFilter   map[Level]bool
+	levels        *Levels
+	caller        bool
+	errorStack    bool
+	sampler       *sampler
+	hooks         []Hook
+	outputs       *outputs
 }
 
 func (l *LoggerImpl) WithTags(tags map[string]any) Logger {
 	return &LoggerImpl{
-		out:          l.out,
-		err:          l.err,
-		tags:         mergeTags(l.tags, tags),
-		format:       l.format,
-		redactFields: l.redactFields,
-		prefix:       l.prefix,
-		levelFilter:  l.levelFilter,
-		hooks:        l.hooks,
+		out:           l.out,
+		err:           l.err,
+		tags:          mergeTags(l.tags, tags),
+		format:        l.format,
+		redactFields:  l.redactFields,
+		valueRedactor: l.valueRedactor,
+		prefix:        l.prefix,
+		levelFilter:   l.levelFilter,
+		levels:        l.levels,
+		caller:        l.caller,
+		errorStack:    l.errorStack,
+		sampler:       l.sampler,
+		hooks:         l.hooks,
+		outputs:       l.outputs,
 	}
 }
 
 func (l *LoggerImpl) WithPrefix(prefix string) Logger {
 	return &LoggerImpl{
-		out:          l.out,
-		err:          l.err,
-		tags:         l.tags,
-		format:       l.format,
-		redactFields: l.redactFields,
-		prefix:       prefix,
-		levelFilter:  l.levelFilter,
-		hooks:        l.hooks,
+		out:           l.out,
+		err:           l.err,
+		tags:          l.tags,
+		format:        l.format,
+		redactFields:  l.redactFields,
+		valueRedactor: l.valueRedactor,
+		prefix:        prefix,
+		levelFilter:   l.levelFilter,
+		levels:        l.levels,
+		caller:        l.caller,
+		errorStack:    l.errorStack,
+		sampler:       l.sampler,
+		hooks:         l.hooks,
+		outputs:       l.outputs,
 	}
 }
 
 func (l *LoggerImpl) Debug(msg string) {
-	l.log(l.out, LevelDebug, msg, nil) //nolint:goerr113
+	l.log(l.out, LevelDebug, msg, nil, 0) //nolint:goerr113
 }
 
 func (l *LoggerImpl) Info(msg string) {
-	l.log(l.out, LevelInfo, msg, nil) //nolint:goerr113
+	l.log(l.out, LevelInfo, msg, nil, 0) //nolint:goerr113
 }
 
 func (l *LoggerImpl) Warn(msg string, err error) {
-	l.log(l.err, LevelWarn, msg, err)
+	l.log(l.err, LevelWarn, msg, err, 0)
 }
 
 func (l *LoggerImpl) Error(msg string, err error) {
-	l.log(l.err, LevelError, msg, err)
+	l.log(l.err, LevelError, msg, err, 0)
+}
+
+// Flush waits until the logs buffered in async mode have been written.
+func (l *LoggerImpl) Flush() {
+	l.outputs.Flush()
+}
+
+// DroppedLines returns the number of logs dropped in async mode because the buffer was full.
+func (l *LoggerImpl) DroppedLines() uint64 {
+	return l.outputs.Dropped()
+}
+
+// Close writes the logs buffered in async mode and closes the log files opened by the logger. Since loggers
+// created with WithTags and WithPrefix share the files, they should not be used once the logger is closed.
+// Messages that are still being suppressed by sampling are summarized before the files are closed.
+func (l *LoggerImpl) Close() error {
+	for _, s := range l.sampler.Flush() {
+		l.logSuppressed(s)
+	}
+
+	return l.outputs.Close()
+}
+
+// Levels returns the levels used by the logger, which can be changed at runtime. It returns nil if the logger was
+// created without levels.
+func (l *LoggerImpl) Levels() *Levels {
+	return l.levels
+}
+
+func (l *LoggerImpl) enabled(lvl Level) bool {
+	return l.enabledWithPrefix(l.prefix, lvl)
 }
 
-func (l *LoggerImpl) log(dest io.Writer, lvl Level, msg string, err error) {
-	// Filter by level (if level filter is set)
-	if l.levelFilter != nil && !l.levelFilter[lvl] {
+func (l *LoggerImpl) enabledWithPrefix(prefix string, lvl Level) bool {
+	return levelEnabled(l.levelFilter, l.levels, prefix, lvl)
+}
+
+func (l *LoggerImpl) logWithCaller(lvl Level, msg string, err error, pc uintptr) {
+	dest := l.out
+	if lvl >= LevelWarn {
+		dest = l.err
+	}
+
+	l.log(dest, lvl, msg, err, pc)
+}
+
+// log writes the log if its level is enabled and it is sampled. The caller is the code that called the Logger method
+// unless pc, the program counter of the code that wrote the log, is set.
+func (l *LoggerImpl) log(dest io.Writer, lvl Level, msg string, err error, pc uintptr) {
+	prefix := l.prefix
+	if prefix == "" {
+		prefix = msgPrefix(msg)
+	}
+
+	if !l.enabledWithPrefix(prefix, lvl) {
+		return
+	}
+
+	ok, suppressed := l.sampler.Sample(lvl, prefix, msg)
+	for _, s := range suppressed {
+		l.logSuppressed(s)
+	}
+
+	if !ok {
 		return
 	}
 
+	var caller *logCaller
+	if withStack := l.errorStack && lvl == LevelError; l.caller || withStack {
+		caller = captureCaller(withStack, pc)
+	}
+
+	l.write(dest, lvl, msg, err, caller)
+}
+
+// logSuppressed logs a summary of the messages suppressed by sampling with the level and prefix they were logged with.
+func (l *LoggerImpl) logSuppressed(s suppressedSummary) {
+	dest := l.out
+	if s.level >= LevelWarn {
+		dest = l.err
+	}
+
+	logger := l.WithPrefix(s.prefix).WithTags(suppressedTags(s.msg, s.count)).(*LoggerImpl) //nolint:forcetypeassert
+
+	logger.write(dest, s.level, suppressedMsg(s.count), nil, nil)
+}
+
+func (l *LoggerImpl) write(dest io.Writer, lvl Level, msg string, err error, caller *logCaller) {
+	// Text formats are redacted a line at a time since the line does not have a structure to preserve
+	if l.valueRedactor != nil && l.format != FormatJSON {
+		dest = redactingWriter{w: dest, r: l.valueRedactor}
+	}
+
 	switch l.format {
 	case FormatJSON:
-		l.logJSON(dest, lvl, msg, err)
+		l.logJSON(dest, lvl, msg, err, caller)
+	case FormatLogfmt:
+		l.logLogfmt(dest, lvl, msg, err, caller)
+	case FormatConsole:
+		l.logConsole(dest, lvl, msg, err, caller)
 	case FormatPlain:
 		fallthrough
 	default:
-		l.logPlain(dest, lvl, msg, err)
+		l.logPlain(dest, lvl, msg, err, caller)
 	}
 
 	for i := range l.hooks {
@@ -204,7 +304,7 @@ func (l *LoggerImpl) log(dest io.Writer, lvl Level, msg string, err error) {
 	}
 }
 
-func (l *LoggerImpl) logJSON(dest io.Writer, lvl Level, msg string, err error) {
+func (l *LoggerImpl) logJSON(dest io.Writer, lvl Level, msg string, err error, caller *logCaller) {
 	if l.prefix != "" {
 		msg = "[" + l.prefix + "] " + msg
 	}
@@ -212,46 +312,86 @@ func (l *LoggerImpl) logJSON(dest io.Writer, lvl Level, msg string, err error) {
 	var dict = map[string]any{
 		"ts":    time.Now().Format(time.RFC3339),
 		"level": lvl.String(),
-		"msg":   msg,
+		"msg":   l.valueRedactor.String(msg),
 	}
 
 	if err != nil {
-		dict["error"] = cerrors.WithoutTags(err).Error()
+		dict["error"] = l.redactedErrorJSON(err)
 	}
 
-	if redactedTags, err := redactJSONObject(mergeTags(cerrors.Tags(err), l.tags), l.redactFields); err != nil {
+	if caller != nil {
+		dict["caller"] = caller.String()
+
+		if len(caller.stack) > 0 {
+			dict["stack"] = caller.stack
+		}
+	}
+
+	if redactedTags, err := redactJSONObject(l.tags, l.redactFields); err != nil {
 		dict["tags"] = cerrors.New(err, "tag redaction failed", nil).Error()
 	} else {
-		dict["tags"] = redactedTags
+		dict["tags"] = l.valueRedactor.Value(redactedTags)
+	}
+
+	lb := getLineBuffer()
+	defer putLineBuffer(lb)
+
+	_ = lb.enc.Encode(dict)
+	_, _ = dest.Write(lb.buf.Bytes())
+}
+
+// redactedErrorJSON returns the error chain with both the redacted fields and the sensitive values redacted.
+func (l *LoggerImpl) redactedErrorJSON(err error) any {
+	redactedErr, redactErr := redactJSONValue(cerrors.ChainOf(err), l.redactFields)
+	if redactErr == nil {
+		redactedErr, redactErr = l.valueRedactor.RawJSON(redactedErr)
 	}
 
-	enc := json.NewEncoder(dest)
-	enc.SetEscapeHTML(false)
+	if redactErr != nil {
+		return cerrors.New(redactErr, "error redaction failed", nil).Error()
+	}
 
-	_ = enc.Encode(dict)
+	return redactedErr
 }
 
-func (l *LoggerImpl) logPlain(dest io.Writer, lvl Level, msg string, err error) {
+func (l *LoggerImpl) logPlain(dest io.Writer, lvl Level, msg string, err error, caller *logCaller) {
 	if l.prefix != "" {
 		msg = "[" + l.prefix + "] " + msg
 	}
 
-	var (
-		logErr = cerrors.New(nil, msg, l.tags).Error()
+	const plainTimeFormat = "2006/01/02 15:04:05 "
 
-		o strings.Builder
-	)
+	tags := l.tags
+
+	if len(l.redactFields) > 0 {
+		tags = redactTags(tags, l.redactFields)
+		err = cerrors.MapTags(err, func(errTags map[string]any) map[string]any {
+			return redactTags(errTags, l.redactFields)
+		})
+	}
 
-	if len(l.redactFields) == 0 {
-		o.WriteString(logErr)
+	lb := getLineBuffer()
+	defer putLineBuffer(lb)
 
-		if err != nil {
-			o.WriteString(" because\n> ")
-			o.WriteString(err.Error())
-		}
-	} else {
-		o.WriteString("<field redacting not supported for plain logs>")
+	lb.buf.WriteString(time.Now().Format(plainTimeFormat))
+	lb.buf.WriteString("[" + lvl.String() + "] ")
+
+	if caller != nil {
+		lb.buf.WriteString(caller.String() + ": ")
+	}
+
+	lb.buf.WriteString(cerrors.New(nil, msg, tags).Error())
+
+	if err != nil {
+		lb.buf.WriteString(" because\n> ")
+		lb.buf.WriteString(err.Error())
+	}
+
+	lb.buf.WriteByte('\n')
+
+	if caller != nil {
+		caller.writeStack(&lb.buf, "\t")
 	}
 
-	log.New(dest, "", log.LstdFlags).Printf("[%s] %s", lvl.String(), o.String())
+	_, _ = dest.Write(lb.buf.Bytes())
 }
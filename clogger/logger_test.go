@@ -2,7 +2,9 @@ package clogger_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"testing"
 
@@ -190,3 +192,85 @@ func TestLogger_Error(t *testing.T) {
 
 	assert.Contains(t, buf.String(), "[ERROR] test error log because\n> test-error")
 }
+
+func TestLogger_Error_JSON(t *testing.T) {
+	t.Parallel()
+
+	var (
+		buf    bytes.Buffer
+		logger = clogger.NewWithWriters(&buf, &buf, clogger.FormatJSON, nil, nil, nil)
+		out    struct {
+			Msg   string           `json:"msg"`
+			Tags  map[string]any   `json:"tags"`
+			Error []map[string]any `json:"error"`
+		}
+	)
+
+	logger.WithTags(map[string]any{
+		"key": "val",
+	}).Error("test error log", cerrors.New(cerrors.New(nil, "test-error-2", map[string]any{
+		"key2": "val2",
+	}), "test-error-1", map[string]any{
+		"key1": "val1",
+	}))
+
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+
+	assert.Equal(t, "test error log", out.Msg)
+	assert.Equal(t, map[string]any{"key": "val"}, out.Tags)
+	assert.Len(t, out.Error, 2)
+	assert.Equal(t, "test-error-1", out.Error[0]["message"])
+	assert.Equal(t, map[string]any{"key1": "val1"}, out.Error[0]["tags"])
+	assert.Equal(t, "test-error-2", out.Error[1]["message"])
+	assert.Equal(t, map[string]any{"key2": "val2"}, out.Error[1]["tags"])
+}
+
+func TestLogger_Error_PlainRedactedFields(t *testing.T) {
+	t.Parallel()
+
+	var (
+		buf    bytes.Buffer
+		logger = clogger.NewWithWriters(&buf, &buf, clogger.FormatPlain, []string{"password", "apiKey"}, nil, nil)
+	)
+
+	logger.WithTags(map[string]any{
+		"user":     "test",
+		"password": "abc123",
+		"params": map[string]any{
+			"api_key": "my_api_key",
+			"count":   1000000,
+		},
+	}).Error("test error log", fmt.Errorf("handler failed: %w", cerrors.New(
+		cerrors.New(nil, "test-error-2", map[string]any{"API-KEY": "my_api_key"}),
+		"test-error-1",
+		map[string]any{"userPassword": "abc123", "id": 1},
+	)))
+
+	out := buf.String()
+
+	assert.NotContains(t, out, "abc123")
+	assert.NotContains(t, out, "my_api_key")
+	assert.Contains(t, out, "[ERROR] test error log where params=map[api_key:redacted count:1000000],password=redacted,user=test because")
+	assert.Contains(t, out, "> handler failed because\n> test-error-1 where id=1,userPassword=redacted because\n> test-error-2 where API-KEY=redacted")
+}
+
+func TestLogger_Error_PlainRedactedFields_MultiUnwrap(t *testing.T) {
+	t.Parallel()
+
+	var (
+		buf    bytes.Buffer
+		logger = clogger.NewWithWriters(&buf, &buf, clogger.FormatPlain, []string{"password"}, nil, nil)
+		err1   = cerrors.New(nil, "test-error-1", map[string]any{"password": "abc123"})
+		err2   = cerrors.New(nil, "test-error-2", map[string]any{"password": "def456"})
+	)
+
+	logger.Error("test joined", errors.Join(err1, err2))
+	logger.Error("test wrapped", fmt.Errorf("handler failed: %w, %w", err1, err2))
+
+	out := buf.String()
+
+	assert.NotContains(t, out, "abc123")
+	assert.NotContains(t, out, "def456")
+	assert.Contains(t, out, "test-error-1 where password=redacted")
+	assert.Contains(t, out, "test-error-2 where password=redacted")
+}
@@ -0,0 +1,225 @@
+package clogger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gocopper/copper/cerrors"
+)
+
+// DefaultOTLPEndpoint is the default logs endpoint of an OpenTelemetry collector using OTLP/HTTP
+const DefaultOTLPEndpoint = "http://localhost:4318/v1/logs"
+
+// NewOTLPSender creates a BatchSender that exports records to an OpenTelemetry collector using OTLP/HTTP with JSON
+// encoding. The service name is set as the service.name resource attribute.
+func NewOTLPSender(endpoint, serviceName string, headers map[string]string) *OTLPSender {
+	if endpoint == "" {
+		endpoint = DefaultOTLPEndpoint
+	}
+
+	return &OTLPSender{
+		endpoint:    endpoint,
+		serviceName: serviceName,
+		headers:     headers,
+		client:      &http.Client{},
+	}
+}
+
+// OTLPSender is a BatchSender that exports records as OTLP log records. Tags are flattened into attributes and the
+// trace_id and span_id tags (ex. set by chttp) are used to correlate the records with traces.
+type OTLPSender struct {
+	endpoint    string
+	serviceName string
+	headers     map[string]string
+	client      *http.Client
+}
+
+type (
+	otlpLogsRequest struct {
+		ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+	}
+
+	otlpResourceLogs struct {
+		Resource  otlpResource    `json:"resource"`
+		ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+	}
+
+	otlpResource struct {
+		Attributes []otlpAttribute `json:"attributes"`
+	}
+
+	otlpScopeLogs struct {
+		Scope      otlpScope       `json:"scope"`
+		LogRecords []otlpLogRecord `json:"logRecords"`
+	}
+
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+
+	otlpLogRecord struct {
+		TimeUnixNano         string          `json:"timeUnixNano"`
+		ObservedTimeUnixNano string          `json:"observedTimeUnixNano"`
+		SeverityNumber       int             `json:"severityNumber"`
+		SeverityText         string          `json:"severityText"`
+		Body                 otlpAnyValue    `json:"body"`
+		Attributes           []otlpAttribute `json:"attributes,omitempty"`
+		TraceID              string          `json:"traceId,omitempty"`
+		SpanID               string          `json:"spanId,omitempty"`
+	}
+
+	otlpAttribute struct {
+		Key   string       `json:"key"`
+		Value otlpAnyValue `json:"value"`
+	}
+
+	otlpAnyValue struct {
+		StringValue *string  `json:"stringValue,omitempty"`
+		BoolValue   *bool    `json:"boolValue,omitempty"`
+		IntValue    *string  `json:"intValue,omitempty"`
+		DoubleValue *float64 `json:"doubleValue,omitempty"`
+	}
+)
+
+// Send exports the records in a single OTLP logs request.
+func (s *OTLPSender) Send(ctx context.Context, records []Record) error {
+	logRecords := make([]otlpLogRecord, 0, len(records))
+	for _, r := range records {
+		logRecords = append(logRecords, otlpRecord(r))
+	}
+
+	req := otlpLogsRequest{
+		ResourceLogs: []otlpResourceLogs{{
+			Resource: otlpResource{
+				Attributes: []otlpAttribute{{Key: "service.name", Value: otlpValue(s.serviceName)}},
+			},
+			ScopeLogs: []otlpScopeLogs{{
+				Scope:      otlpScope{Name: "github.com/gocopper/copper/clogger"},
+				LogRecords: logRecords,
+			}},
+		}},
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return permanentError{err: cerrors.New(err, "failed to encode otlp logs request", nil)}
+	}
+
+	return postBatch(ctx, s.client, s.endpoint, "application/json", s.headers, bytes.NewReader(body))
+}
+
+func otlpRecord(r Record) otlpLogRecord {
+	ts := strconv.FormatInt(r.Time.UnixNano(), 10)
+
+	lr := otlpLogRecord{
+		TimeUnixNano:         ts,
+		ObservedTimeUnixNano: ts,
+		SeverityNumber:       otlpSeverity(r.Level),
+		SeverityText:         r.Level.String(),
+		Body:                 otlpValue(r.Msg),
+		Attributes:           make([]otlpAttribute, 0, len(r.Tags)),
+	}
+
+	lr.Attributes = otlpAttributes(lr.Attributes, "", r.Tags)
+
+	for _, attr := range lr.Attributes {
+		if attr.Value.StringValue == nil {
+			continue
+		}
+
+		switch attr.Key {
+		case "trace_id":
+			lr.TraceID = *attr.Value.StringValue
+		case "span_id":
+			lr.SpanID = *attr.Value.StringValue
+		}
+	}
+
+	if r.Err != nil {
+		lr.Attributes = append(lr.Attributes, otlpAttribute{Key: "exception.message", Value: otlpValue(r.Err.Error())})
+	}
+
+	return lr
+}
+
+// otlpAttributes appends the tags as attributes sorted by key. The keys of nested tags are joined with dots
+// (ex. "req.method").
+func otlpAttributes(attrs []otlpAttribute, prefix string, tags map[string]any) []otlpAttribute {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		if nested, ok := tags[k].(map[string]any); ok {
+			attrs = otlpAttributes(attrs, key, nested)
+			continue
+		}
+
+		attrs = append(attrs, otlpAttribute{Key: key, Value: otlpValue(tags[k])})
+	}
+
+	return attrs
+}
+
+// otlpValue converts a tag value to an OTLP AnyValue. Values that do not have a matching OTLP type are converted
+// to strings.
+func otlpValue(v any) otlpAnyValue {
+	switch val := v.(type) {
+	case bool:
+		return otlpAnyValue{BoolValue: &val}
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		s := fmt.Sprint(val)
+		return otlpAnyValue{IntValue: &s}
+	case float32:
+		f := float64(val)
+		return otlpAnyValue{DoubleValue: &f}
+	case float64:
+		return otlpAnyValue{DoubleValue: &val}
+	case json.Number:
+		if _, err := val.Int64(); err == nil {
+			s := val.String()
+			return otlpAnyValue{IntValue: &s}
+		}
+
+		if f, err := val.Float64(); err == nil {
+			return otlpAnyValue{DoubleValue: &f}
+		}
+
+		s := val.String()
+
+		return otlpAnyValue{StringValue: &s}
+	default:
+		s := tagValueString(val)
+		return otlpAnyValue{StringValue: &s}
+	}
+}
+
+// otlpSeverity returns the OTLP severity number of the level.
+// See https://opentelemetry.io/docs/specs/otel/logs/data-model/#field-severitynumber
+func otlpSeverity(lvl Level) int {
+	switch lvl {
+	case LevelDebug:
+		return 5
+	case LevelInfo:
+		return 9
+	case LevelWarn:
+		return 13
+	case LevelError:
+		return 17
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,205 @@
+package clogger
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gocopper/copper/cerrors"
+)
+
+// shipTimeout limits how long flushing and closing the shipping hooks can take
+const shipTimeout = 10 * time.Second
+
+// outputs holds the destinations of logs configured by Config along with the files, async writers, and shipping
+// hooks that are closed with the logger. A nil *outputs is valid and has nothing to flush or close.
+type outputs struct {
+	out   io.Writer
+	err   io.Writer
+	async []*asyncWriter
+	hooks []*BatchHook
+
+	closeOnce sync.Once
+	closers   []io.Closer
+	closeErr  error
+}
+
+func openOutputs(config Config) (*outputs, error) {
+	o := &outputs{
+		out:     os.Stdout,
+		err:     os.Stderr,
+		closers: make([]io.Closer, 0),
+	}
+
+	if config.Out != "" {
+		f, err := OpenRotatingFile(config.Out, config.rotateOptions())
+		if err != nil {
+			return nil, cerrors.New(err, "failed to open log file", map[string]any{
+				"path": config.Out,
+			})
+		}
+
+		o.out = f
+		o.closers = append(o.closers, f)
+	}
+
+	if config.Out == config.Err {
+		o.err = o.out
+	} else if config.Err != "" {
+		f, err := OpenRotatingFile(config.Err, config.rotateOptions())
+		if err != nil {
+			_ = o.Close()
+
+			return nil, cerrors.New(err, "failed to open error log file", map[string]any{
+				"path": config.Err,
+			})
+		}
+
+		o.err = f
+		o.closers = append(o.closers, f)
+	}
+
+	if config.Async {
+		outAsync := newAsyncWriter(o.out, int(config.AsyncBufferSize), config.AsyncOverflow)
+		o.async = append(o.async, outAsync)
+
+		if o.err == o.out {
+			o.err = outAsync
+		} else {
+			errAsync := newAsyncWriter(o.err, int(config.AsyncBufferSize), config.AsyncOverflow)
+			o.async = append(o.async, errAsync)
+			o.err = errAsync
+		}
+
+		o.out = outAsync
+
+		// Async writers are closed before the files so that their buffered lines are written
+		closers := make([]io.Closer, 0, len(o.async)+len(o.closers))
+		for _, aw := range o.async {
+			closers = append(closers, aw)
+		}
+
+		o.closers = append(closers, o.closers...)
+	}
+
+	if config.Syslog {
+		o.hooks = append(o.hooks, NewBatchHook(
+			NewSyslogSender(config.SyslogNetwork, config.SyslogAddr, config.SyslogTag),
+			config.batchOptions(),
+		))
+	}
+
+	if config.HTTPURL != "" {
+		o.hooks = append(o.hooks, NewBatchHook(
+			NewHTTPSender(config.HTTPURL, config.HTTPHeaders),
+			config.batchOptions(),
+		))
+	}
+
+	if config.OTLPEndpoint != "" {
+		o.hooks = append(o.hooks, NewBatchHook(
+			NewOTLPSender(config.OTLPEndpoint, config.OTLPServiceName, config.OTLPHeaders),
+			config.batchOptions(),
+		))
+	}
+
+	return o, nil
+}
+
+// withHooks returns the given hooks along with the shipping hooks configured by Config. All of the hooks are given
+// the logs redacted the same way the logger redacts them, so that sensitive values are not sent to external services.
+func (o *outputs) withHooks(hooks []Hook, redactFields []string, valueRedactor *valueRedactor) []Hook {
+	hooks = redactHooks(hooks, redactFields, valueRedactor)
+
+	if o == nil || len(o.hooks) == 0 {
+		return hooks
+	}
+
+	all := make([]Hook, 0, len(hooks)+len(o.hooks))
+	all = append(all, hooks...)
+
+	for _, h := range o.hooks {
+		h.redact = recordRedactor(redactFields, valueRedactor)
+		all = append(all, h)
+	}
+
+	return all
+}
+
+// Flush waits until the logs buffered by the async writers have been written.
+func (o *outputs) Flush() {
+	if o == nil {
+		return
+	}
+
+	for _, aw := range o.async {
+		aw.Flush()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shipTimeout)
+	defer cancel()
+
+	for _, h := range o.hooks {
+		_ = h.Flush(ctx)
+	}
+}
+
+// Dropped returns the number of logs dropped by the async writers because their buffer was full.
+func (o *outputs) Dropped() uint64 {
+	if o == nil {
+		return 0
+	}
+
+	var dropped uint64
+	for _, aw := range o.async {
+		dropped += aw.Dropped()
+	}
+
+	return dropped
+}
+
+// Close sends the pending logs of the shipping hooks, and closes the async writers and the files exactly once, even
+// if it is called by multiple loggers that share the outputs.
+func (o *outputs) Close() error {
+	if o == nil {
+		return nil
+	}
+
+	o.closeOnce.Do(func() {
+		errs := make([]error, 0)
+
+		ctx, cancel := context.WithTimeout(context.Background(), shipTimeout)
+		defer cancel()
+
+		for _, h := range o.hooks {
+			err := h.Close(ctx)
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		for _, c := range o.closers {
+			err := c.Close()
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		o.closeErr = cerrors.Join(errs...)
+	})
+
+	return o.closeErr
+}
+
+// levelEnabled returns true if a log with the given prefix and level passes both the level filter and the levels.
+// Either of them may be nil.
+func levelEnabled(levelFilter map[Level]bool, levels *Levels, prefix string, lvl Level) bool {
+	// Filter by level (if level filter is set)
+	if levelFilter != nil && !levelFilter[lvl] {
+		return false
+	}
+
+	return levels == nil || levels.Enabled(prefix, lvl)
+}
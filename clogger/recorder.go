@@ -2,7 +2,8 @@ package clogger
 
 // NewRecorder returns an implementation of Logger that keeps
 // a record of each log. Useful in unit tests when logs need
-// to be tested.
+// to be tested. It is not safe for concurrent use - see
+// cloggertest.Recorder for a concurrency-safe recorder with assertions.
 func NewRecorder(logs *[]RecordedLog) Logger {
 	return &recorder{
 		Logs: logs,
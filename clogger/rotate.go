@@ -0,0 +1,458 @@
+package clogger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gocopper/copper/cerrors"
+)
+
+const (
+	logFilePerms         = 0666
+	backupTimeFormat     = "2006-01-02T15-04-05.000"
+	compressedBackupExt  = ".gz"
+	bytesInMB            = 1024 * 1024
+	openLogFileFlags     = os.O_APPEND | os.O_CREATE | os.O_WRONLY
+	compressedBackupPerm = 0644
+)
+
+var (
+	rotatingFilesMu sync.Mutex                       //nolint:gochecknoglobals
+	rotatingFiles   = make(map[string]*RotatingFile) //nolint:gochecknoglobals
+)
+
+// RotateOptions configures when a RotatingFile is rotated and how its backups are kept. Zero values disable the
+// respective feature.
+type RotateOptions struct {
+	// MaxSize is the size in bytes after which the file is rotated
+	MaxSize int64
+	// MaxAge is the duration after which the file is rotated, measured from when it was opened by this process. The
+	// age is not persisted, so restarting the process starts it again even if the file already has logs in it.
+	MaxAge time.Duration
+	// MaxBackups is the number of rotated files that are kept. Older ones are deleted.
+	MaxBackups int
+	// Compress gzips rotated files
+	Compress bool
+	// ReopenOnSIGHUP reopens the file when the process receives SIGHUP so that it can be moved by external tools
+	// such as logrotate
+	ReopenOnSIGHUP bool
+}
+
+// RotatingFile is an io.WriteCloser that appends to a log file and rotates it based on its size and age. Rotated
+// files are renamed to include the time of rotation (ex. app-2006-01-02T15-04-05.000.log).
+//
+// Opening the same path multiple times returns the same RotatingFile so that multiple loggers can share it. The file
+// is closed once all of them have closed it.
+type RotatingFile struct {
+	mu       sync.Mutex
+	path     string
+	opts     RotateOptions
+	file     *os.File
+	closed   bool
+	size     int64
+	openedAt time.Time
+	refs     int
+	sighup   chan os.Signal
+	done     chan struct{}
+	wg       sync.WaitGroup
+
+	// compressing holds the names of the backups that are being compressed so that they are not removed
+	compressing map[string]struct{}
+}
+
+// OpenRotatingFile opens the log file at the given path for appending, creating it if needed. If the path is already
+// open, the existing RotatingFile is returned and its options are left unchanged.
+func OpenRotatingFile(path string, opts RotateOptions) (*RotatingFile, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, cerrors.New(err, "failed to get absolute path of log file", map[string]any{
+			"path": path,
+		})
+	}
+
+	rotatingFilesMu.Lock()
+	defer rotatingFilesMu.Unlock()
+
+	if f, ok := rotatingFiles[absPath]; ok {
+		f.mu.Lock()
+		f.refs++
+		f.mu.Unlock()
+
+		return f, nil
+	}
+
+	f := &RotatingFile{
+		path:        absPath,
+		opts:        opts,
+		refs:        1,
+		done:        make(chan struct{}),
+		compressing: make(map[string]struct{}),
+	}
+
+	err = f.open()
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.ReopenOnSIGHUP {
+		f.sighup = make(chan os.Signal, 1)
+		signal.Notify(f.sighup, syscall.SIGHUP)
+
+		f.wg.Add(1)
+		go f.reopenOnSIGHUP()
+	}
+
+	rotatingFiles[absPath] = f
+
+	return f, nil
+}
+
+// Write appends p to the file, rotating it first if it has reached its max size or age. If the file could not be
+// opened again after a rotation or a reopen, it tries to open it before writing.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return 0, os.ErrClosed
+	}
+
+	if f.file == nil {
+		err := f.open()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if f.shouldRotate(len(p)) {
+		err := f.rotate()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+
+	return n, err //nolint:wrapcheck
+}
+
+// Rotate renames the current file to a backup and opens a new file at the original path.
+func (f *RotatingFile) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return os.ErrClosed
+	}
+
+	return f.rotate()
+}
+
+// Reopen closes the file and opens it again at the same path. It is useful when the file has been moved by an
+// external tool.
+func (f *RotatingFile) Reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return os.ErrClosed
+	}
+
+	err := f.closeFile()
+	if err != nil {
+		return err
+	}
+
+	return f.open()
+}
+
+// Close releases this reference to the file. Once all references are released, the file is closed and pending
+// backup compressions are completed.
+func (f *RotatingFile) Close() error {
+	rotatingFilesMu.Lock()
+
+	f.mu.Lock()
+	f.refs--
+
+	if f.refs > 0 {
+		f.mu.Unlock()
+		rotatingFilesMu.Unlock()
+
+		return nil
+	}
+
+	delete(rotatingFiles, f.path)
+	rotatingFilesMu.Unlock()
+
+	var err error
+	if f.file != nil {
+		err = f.file.Close()
+		f.file = nil
+	}
+
+	f.closed = true
+	f.mu.Unlock()
+
+	if f.sighup != nil {
+		signal.Stop(f.sighup)
+	}
+
+	close(f.done)
+	f.wg.Wait()
+
+	if err != nil {
+		return cerrors.New(err, "failed to close log file", map[string]any{
+			"path": f.path,
+		})
+	}
+
+	return nil
+}
+
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, openLogFileFlags, logFilePerms)
+	if err != nil {
+		return cerrors.New(err, "failed to open log file", map[string]any{
+			"path": f.path,
+		})
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+
+		return cerrors.New(err, "failed to stat log file", map[string]any{
+			"path": f.path,
+		})
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = time.Now()
+
+	return nil
+}
+
+// closeFile closes the current file, if any. The file is unset even if closing it fails so that the closed handle is
+// not written to.
+func (f *RotatingFile) closeFile() error {
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+
+	if err != nil {
+		return cerrors.New(err, "failed to close log file", map[string]any{
+			"path": f.path,
+		})
+	}
+
+	return nil
+}
+
+func (f *RotatingFile) shouldRotate(writeLen int) bool {
+	if f.size == 0 {
+		return false
+	}
+
+	if f.opts.MaxSize > 0 && f.size+int64(writeLen) > f.opts.MaxSize {
+		return true
+	}
+
+	return f.opts.MaxAge > 0 && time.Since(f.openedAt) >= f.opts.MaxAge
+}
+
+func (f *RotatingFile) rotate() error {
+	err := f.closeFile()
+	if err != nil {
+		return err
+	}
+
+	// Ensure that a backup rotated within the same millisecond as a previous one does not replace it
+	rotatedAt := time.Now()
+	backup := f.backupPath(rotatedAt)
+
+	for backupExists(backup) {
+		rotatedAt = rotatedAt.Add(time.Millisecond)
+		backup = f.backupPath(rotatedAt)
+	}
+
+	err = os.Rename(f.path, backup)
+	if err != nil {
+		// Keep writing to the current file since it could not be rotated
+		_ = f.open()
+
+		return cerrors.New(err, "failed to rename log file", map[string]any{
+			"path":   f.path,
+			"backup": backup,
+		})
+	}
+
+	err = f.open()
+	if err != nil {
+		return err
+	}
+
+	if !f.opts.Compress {
+		f.removeOldBackups()
+		return nil
+	}
+
+	f.compressing[filepath.Base(backup)] = struct{}{}
+
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+
+		// Remove old backups only after compressing so that the backup being compressed is counted once. If the
+		// compression fails, the uncompressed backup is kept and the error is written to stderr since the log file
+		// itself may be the one failing.
+		err := compressFile(backup)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "clogger: %s\n", err.Error())
+		}
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		delete(f.compressing, filepath.Base(backup))
+		f.removeOldBackups()
+	}()
+
+	return nil
+}
+
+func (f *RotatingFile) reopenOnSIGHUP() {
+	defer f.wg.Done()
+
+	for {
+		select {
+		case <-f.sighup:
+			_ = f.Reopen()
+		case <-f.done:
+			return
+		}
+	}
+}
+
+// backupPath returns the path of a backup rotated at the given time (ex. /var/log/app-2006-01-02T15-04-05.000.log).
+func (f *RotatingFile) backupPath(t time.Time) string {
+	ext := filepath.Ext(f.path)
+	return strings.TrimSuffix(f.path, ext) + "-" + t.UTC().Format(backupTimeFormat) + ext
+}
+
+// removeOldBackups deletes the oldest backups so that at most MaxBackups are kept. Backups that are being compressed
+// are skipped, and the old backups are removed again once their compression is done.
+func (f *RotatingFile) removeOldBackups() {
+	if f.opts.MaxBackups <= 0 {
+		return
+	}
+
+	var (
+		ext     = filepath.Ext(f.path)
+		prefix  = filepath.Base(strings.TrimSuffix(f.path, ext)) + "-"
+		dir     = filepath.Dir(f.path)
+		backups = make([]string, 0)
+	)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), compressedBackupExt)
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+
+		if _, ok := f.compressing[name]; ok {
+			continue
+		}
+
+		_, err := time.Parse(backupTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext))
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, entry.Name())
+	}
+
+	// Backup names sort by their rotation time since the time format is lexically ordered
+	sort.Strings(backups)
+
+	for i := 0; i < len(backups)-f.opts.MaxBackups; i++ {
+		_ = os.Remove(filepath.Join(dir, backups[i]))
+	}
+}
+
+func backupExists(path string) bool {
+	if _, err := os.Stat(path); err == nil {
+		return true
+	}
+
+	_, err := os.Stat(path + compressedBackupExt)
+
+	return err == nil
+}
+
+// compressFile gzips the file at the given path and removes it. If the compression fails, the partially written
+// compressed file is removed and the original file is kept.
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return cerrors.New(err, "failed to open log backup", map[string]any{
+			"path": path,
+		})
+	}
+	defer func() { _ = src.Close() }()
+
+	dstPath := path + compressedBackupExt
+
+	dst, err := os.OpenFile(dstPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, compressedBackupPerm)
+	if err != nil {
+		return cerrors.New(err, "failed to create compressed log backup", map[string]any{
+			"path": path,
+		})
+	}
+
+	gz := gzip.NewWriter(dst)
+
+	_, err = io.Copy(gz, src)
+	if err == nil {
+		err = gz.Close()
+	}
+
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(dstPath)
+
+		return cerrors.New(err, "failed to compress log backup", map[string]any{
+			"path": path,
+		})
+	}
+
+	err = os.Remove(path)
+	if err != nil {
+		return cerrors.New(err, "failed to remove uncompressed log backup", map[string]any{
+			"path": path,
+		})
+	}
+
+	return nil
+}
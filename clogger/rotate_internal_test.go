@@ -0,0 +1,61 @@
+package clogger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompressFile_Failure(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "app-backup.log")
+
+	assert.NoError(t, os.WriteFile(path, []byte("test-log\n"), logFilePerms))
+	assert.NoError(t, os.Mkdir(path+compressedBackupExt, 0755))
+
+	assert.Error(t, compressFile(path))
+
+	contents, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "test-log\n", string(contents))
+}
+
+func TestRotatingFile_RemoveOldBackups_Compressing(t *testing.T) {
+	t.Parallel()
+
+	var (
+		dir     = t.TempDir()
+		path    = filepath.Join(dir, "app.log")
+		oldest  = "app-2006-01-02T15-04-05.000.log"
+		newest  = "app-2006-01-02T15-04-06.000.log.gz"
+		backups = []string{oldest, oldest + compressedBackupExt, newest}
+	)
+
+	for _, backup := range backups {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, backup), []byte("test-log\n"), logFilePerms))
+	}
+
+	f := &RotatingFile{
+		path:        path,
+		opts:        RotateOptions{MaxBackups: 1},
+		compressing: map[string]struct{}{oldest: {}},
+	}
+
+	// The oldest backup is being compressed, so neither it nor its partially compressed file are removed
+	f.removeOldBackups()
+
+	for _, backup := range backups {
+		assert.FileExists(t, filepath.Join(dir, backup))
+	}
+
+	delete(f.compressing, oldest)
+	assert.NoError(t, os.Remove(filepath.Join(dir, oldest)))
+
+	f.removeOldBackups()
+
+	assert.NoFileExists(t, filepath.Join(dir, oldest+compressedBackupExt))
+	assert.FileExists(t, filepath.Join(dir, newest))
+}
@@ -0,0 +1,150 @@
+package clogger_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gocopper/copper/clogger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotatingFile_MaxSize(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	f, err := clogger.OpenRotatingFile(filepath.Join(dir, "app.log"), clogger.RotateOptions{
+		MaxSize:    10,
+		MaxBackups: 2,
+	})
+	assert.NoError(t, err)
+
+	for _, line := range []string{"line-0001\n", "line-0002\n", "line-0003\n", "line-0004\n"} {
+		_, err = f.Write([]byte(line))
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, f.Close())
+
+	current, err := os.ReadFile(filepath.Join(dir, "app.log"))
+	assert.NoError(t, err)
+	assert.Equal(t, "line-0004\n", string(current))
+
+	backups, err := filepath.Glob(filepath.Join(dir, "app-*.log"))
+	assert.NoError(t, err)
+	assert.Len(t, backups, 2)
+}
+
+func TestRotatingFile_Compress(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	f, err := clogger.OpenRotatingFile(filepath.Join(dir, "app.log"), clogger.RotateOptions{
+		Compress: true,
+	})
+	assert.NoError(t, err)
+
+	_, err = f.Write([]byte("test-line\n"))
+	assert.NoError(t, err)
+
+	assert.NoError(t, f.Rotate())
+	assert.NoError(t, f.Close())
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	var compressed bool
+	for _, entry := range entries {
+		compressed = compressed || strings.HasSuffix(entry.Name(), ".log.gz")
+	}
+
+	assert.True(t, compressed)
+}
+
+func TestRotatingFile_Reopen(t *testing.T) {
+	t.Parallel()
+
+	var (
+		dir  = t.TempDir()
+		path = filepath.Join(dir, "app.log")
+	)
+
+	f, err := clogger.OpenRotatingFile(path, clogger.RotateOptions{})
+	assert.NoError(t, err)
+
+	_, err = f.Write([]byte("before\n"))
+	assert.NoError(t, err)
+
+	// Simulate logrotate moving the file
+	assert.NoError(t, os.Rename(path, filepath.Join(dir, "app.log.1")))
+	assert.NoError(t, f.Reopen())
+
+	_, err = f.Write([]byte("after\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	current, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "after\n", string(current))
+}
+
+func TestRotatingFile_ReopenFailure(t *testing.T) {
+	t.Parallel()
+
+	var (
+		dir  = t.TempDir()
+		path = filepath.Join(dir, "app.log")
+	)
+
+	f, err := clogger.OpenRotatingFile(path, clogger.RotateOptions{})
+	assert.NoError(t, err)
+
+	// The file cannot be opened again while a directory is at its path
+	assert.NoError(t, os.Rename(path, filepath.Join(dir, "app.log.1")))
+	assert.NoError(t, os.Mkdir(path, 0755))
+	assert.Error(t, f.Reopen())
+
+	_, err = f.Write([]byte("failed\n"))
+	assert.Error(t, err)
+
+	// The file is opened again on the next write once it can be
+	assert.NoError(t, os.Remove(path))
+
+	_, err = f.Write([]byte("after\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	current, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "after\n", string(current))
+
+	_, err = f.Write([]byte("closed\n"))
+	assert.ErrorIs(t, err, os.ErrClosed)
+}
+
+func TestOpenRotatingFile_Shared(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	f1, err := clogger.OpenRotatingFile(path, clogger.RotateOptions{})
+	assert.NoError(t, err)
+
+	f2, err := clogger.OpenRotatingFile(path, clogger.RotateOptions{})
+	assert.NoError(t, err)
+	assert.Same(t, f1, f2)
+
+	assert.NoError(t, f1.Close())
+
+	_, err = f2.Write([]byte("test-line\n"))
+	assert.NoError(t, err)
+
+	assert.NoError(t, f2.Close())
+
+	_, err = f2.Write([]byte("test-line\n"))
+	assert.ErrorIs(t, err, os.ErrClosed)
+}
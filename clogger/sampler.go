@@ -0,0 +1,299 @@
+package clogger
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gocopper/copper/cerrors"
+)
+
+// maxSampledMessages is the number of distinct messages tracked by the sampler before it evicts the ones whose
+// interval has ended.
+const maxSampledMessages = 4096
+
+// SamplingRule limits how often identical messages are logged. Within each interval, the first First messages are
+// logged and then every Thereafter-th message is logged. The rest are suppressed and summarized with a single
+// "Suppressed N similar messages" log once the interval has ended and any message is logged. A rule must log at
+// least one message per interval, so First and Thereafter cannot both be 0.
+//
+// A rule applies to the logs with its level and prefix. An empty level matches all levels and an empty prefix matches
+// all prefixes. When multiple rules match, the one with the longest prefix wins, followed by the one with a level.
+type SamplingRule struct {
+	Level           string `toml:"level"`
+	Prefix          string `toml:"prefix"`
+	First           uint   `toml:"first"`
+	Thereafter      uint   `toml:"thereafter"`
+	IntervalSeconds uint   `toml:"interval_seconds"`
+}
+
+type sampleRule struct {
+	level      Level
+	prefix     string
+	first      uint64
+	thereafter uint64
+	interval   time.Duration
+}
+
+type sampleKey struct {
+	level  Level
+	prefix string
+	msg    string
+}
+
+type sampleCounter struct {
+	start      time.Time
+	interval   time.Duration
+	count      uint64
+	suppressed uint64
+}
+
+// suppressedSummary describes messages that were suppressed by the sampler in an interval.
+type suppressedSummary struct {
+	level  Level
+	prefix string
+	msg    string
+	count  uint64
+}
+
+// sampler decides which logs are written based on the sampling rules. It is shared by a logger and the loggers
+// derived from it. A nil *sampler writes all logs.
+type sampler struct {
+	rules       []sampleRule
+	minInterval time.Duration
+
+	// nextSweep is when the counters are next checked for intervals that have ended, in unix nanoseconds
+	nextSweep atomic.Int64
+
+	mu       sync.Mutex
+	counters map[sampleKey]*sampleCounter
+}
+
+func newSampler(rules []SamplingRule) (*sampler, error) {
+	if len(rules) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	s := &sampler{
+		rules:    make([]sampleRule, 0, len(rules)),
+		counters: make(map[sampleKey]*sampleCounter),
+	}
+
+	for _, rule := range rules {
+		var lvl Level
+
+		if rule.Level != "" {
+			var err error
+
+			lvl, err = ParseLevel(rule.Level)
+			if err != nil {
+				return nil, cerrors.New(err, "invalid sampling rule level", map[string]any{
+					"prefix": rule.Prefix,
+				})
+			}
+		}
+
+		if rule.First == 0 && rule.Thereafter == 0 {
+			return nil, cerrors.New(nil, "sampling rule suppresses all messages", map[string]any{
+				"level":  rule.Level,
+				"prefix": rule.Prefix,
+			})
+		}
+
+		interval := time.Duration(rule.IntervalSeconds) * time.Second
+		if interval == 0 {
+			interval = time.Second
+		}
+
+		if s.minInterval == 0 || interval < s.minInterval {
+			s.minInterval = interval
+		}
+
+		s.rules = append(s.rules, sampleRule{
+			level:      lvl,
+			prefix:     trimPrefix(rule.Prefix),
+			first:      uint64(rule.First),
+			thereafter: uint64(rule.Thereafter),
+			interval:   interval,
+		})
+	}
+
+	// Sort the rules so that the first match is the most specific one
+	sort.SliceStable(s.rules, func(i, j int) bool {
+		if len(s.rules[i].prefix) != len(s.rules[j].prefix) {
+			return len(s.rules[i].prefix) > len(s.rules[j].prefix)
+		}
+
+		return s.rules[i].level != 0 && s.rules[j].level == 0
+	})
+
+	return s, nil
+}
+
+// Sample returns true if the log should be written. It also returns the messages that were suppressed in intervals
+// that have ended, whether they are of this message or not, so that a summary can be logged for each of them.
+func (s *sampler) Sample(lvl Level, prefix, msg string) (bool, []suppressedSummary) {
+	if s == nil {
+		return true, nil
+	}
+
+	var (
+		now        = time.Now()
+		suppressed = s.sweep(now)
+	)
+
+	rule, ok := s.rule(lvl, prefix)
+	if !ok {
+		return true, suppressed
+	}
+
+	key := sampleKey{level: lvl, prefix: prefix, msg: msg}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.counters[key]
+	if !ok || now.Sub(c.start) >= c.interval {
+		if ok && c.suppressed > 0 {
+			suppressed = append(suppressed, newSuppressedSummary(key, c))
+		} else if !ok && len(s.counters) >= maxSampledMessages {
+			suppressed = s.evict(now)
+		}
+
+		c = &sampleCounter{start: now, interval: rule.interval}
+		s.counters[key] = c
+	}
+
+	c.count++
+
+	if c.count <= rule.first || (rule.thereafter > 0 && (c.count-rule.first)%rule.thereafter == 0) {
+		return true, suppressed
+	}
+
+	c.suppressed++
+
+	return false, suppressed
+}
+
+// sweep removes the counters whose interval has ended and returns the messages they suppressed. Since it has to
+// check all of the counters, it only does so once per the shortest interval of the rules.
+func (s *sampler) sweep(now time.Time) []suppressedSummary {
+	if now.UnixNano() < s.nextSweep.Load() {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.UnixNano() < s.nextSweep.Load() {
+		return nil
+	}
+
+	s.nextSweep.Store(now.Add(s.minInterval).UnixNano())
+
+	var summaries []suppressedSummary
+
+	for key, c := range s.counters {
+		if now.Sub(c.start) < c.interval {
+			continue
+		}
+
+		if c.suppressed > 0 {
+			summaries = append(summaries, newSuppressedSummary(key, c))
+		}
+
+		delete(s.counters, key)
+	}
+
+	return summaries
+}
+
+// Flush returns the messages suppressed in the current intervals and resets them so that they can be summarized
+// before the logger is closed.
+func (s *sampler) Flush() []suppressedSummary {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	summaries := make([]suppressedSummary, 0)
+
+	for key, c := range s.counters {
+		if c.suppressed > 0 {
+			summaries = append(summaries, newSuppressedSummary(key, c))
+		}
+
+		delete(s.counters, key)
+	}
+
+	return summaries
+}
+
+func (s *sampler) rule(lvl Level, prefix string) (sampleRule, bool) {
+	for _, rule := range s.rules {
+		if rule.level != 0 && rule.level != lvl {
+			continue
+		}
+
+		if rule.prefix != "" && !hasPrefixSegment(prefix, rule.prefix) {
+			continue
+		}
+
+		return rule, true
+	}
+
+	return sampleRule{}, false
+}
+
+// evict removes the counters whose interval has ended. If all of them are still active, they are all removed to
+// bound the memory used by the sampler. The messages suppressed by the removed counters are returned so that they
+// can be summarized.
+func (s *sampler) evict(now time.Time) []suppressedSummary {
+	var summaries []suppressedSummary
+
+	evictAll := true
+	for _, c := range s.counters {
+		if now.Sub(c.start) >= c.interval {
+			evictAll = false
+			break
+		}
+	}
+
+	for key, c := range s.counters {
+		if !evictAll && now.Sub(c.start) < c.interval {
+			continue
+		}
+
+		if c.suppressed > 0 {
+			summaries = append(summaries, newSuppressedSummary(key, c))
+		}
+
+		delete(s.counters, key)
+	}
+
+	return summaries
+}
+
+func newSuppressedSummary(key sampleKey, c *sampleCounter) suppressedSummary {
+	return suppressedSummary{
+		level:  key.level,
+		prefix: key.prefix,
+		msg:    key.msg,
+		count:  c.suppressed,
+	}
+}
+
+func suppressedMsg(count uint64) string {
+	return fmt.Sprintf("Suppressed %d similar messages", count)
+}
+
+func suppressedTags(msg string, count uint64) map[string]any {
+	return map[string]any{
+		"suppressed_msg":   msg,
+		"suppressed_count": count,
+	}
+}
@@ -0,0 +1,249 @@
+package clogger_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gocopper/copper/clogger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_Sampling(t *testing.T) {
+	t.Parallel()
+
+	for _, backend := range []string{clogger.BackendStd, clogger.BackendZap} {
+		backend := backend
+
+		t.Run(backend, func(t *testing.T) {
+			t.Parallel()
+
+			out := filepath.Join(t.TempDir(), "out.log")
+
+			logger, err := clogger.New(clogger.Config{
+				Out:     out,
+				Err:     out,
+				Format:  clogger.FormatJSON,
+				Backend: backend,
+				Sampling: []clogger.SamplingRule{
+					{First: 2, Thereafter: 3, IntervalSeconds: 60},
+				},
+			}, nil)
+			assert.NoError(t, err)
+
+			for i := 0; i < 10; i++ {
+				logger.Info("test-msg")
+			}
+
+			logger.Info("test-other-msg")
+
+			assert.NoError(t, logger.(interface{ Close() error }).Close())
+
+			logs := readJSONLogs(t, out)
+			assert.Len(t, logs, 6)
+
+			// 1st, 2nd, 5th, and 8th messages are written
+			for i := 0; i < 4; i++ {
+				assert.Equal(t, "test-msg", logs[i]["msg"])
+			}
+
+			assert.Equal(t, "test-other-msg", logs[4]["msg"])
+
+			assert.Equal(t, "Suppressed 6 similar messages", logs[5]["msg"])
+			assert.Equal(t, "INFO", logs[5]["level"])
+			assert.Equal(t, map[string]any{
+				"suppressed_msg":   "test-msg",
+				"suppressed_count": float64(6),
+			}, logs[5]["tags"])
+		})
+	}
+}
+
+func TestNew_SamplingInterval(t *testing.T) {
+	t.Parallel()
+
+	out := filepath.Join(t.TempDir(), "out.log")
+
+	logger, err := clogger.New(clogger.Config{
+		Out:    out,
+		Err:    out,
+		Format: clogger.FormatJSON,
+		Sampling: []clogger.SamplingRule{
+			{Level: "warn", First: 1, IntervalSeconds: 1},
+		},
+	}, nil)
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		logger.WithPrefix("auth").Warn("test-warn", nil)
+		logger.Info("test-info")
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+
+	logger.WithPrefix("auth").Warn("test-warn", nil)
+
+	assert.NoError(t, logger.(interface{ Close() error }).Close())
+
+	logs := readJSONLogs(t, out)
+
+	msgs := make([]string, len(logs))
+	for i := range logs {
+		msgs[i] = logs[i]["msg"].(string) //nolint:forcetypeassert
+	}
+
+	assert.Equal(t, []string{
+		"[auth] test-warn",
+		"test-info",
+		"test-info",
+		"test-info",
+		"[auth] Suppressed 2 similar messages",
+		"[auth] test-warn",
+	}, msgs)
+	assert.Equal(t, "WARN", logs[4]["level"])
+}
+
+func TestNew_SamplingIntervalOtherMessage(t *testing.T) {
+	t.Parallel()
+
+	out := filepath.Join(t.TempDir(), "out.log")
+
+	logger, err := clogger.New(clogger.Config{
+		Out:    out,
+		Err:    out,
+		Format: clogger.FormatJSON,
+		Sampling: []clogger.SamplingRule{
+			{Level: "warn", First: 1, IntervalSeconds: 1},
+		},
+	}, nil)
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		logger.Warn("test-warn", nil)
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+
+	// The summary is written once the interval has ended even though the message is not logged again
+	logger.Info("test-info")
+
+	logs := readJSONLogs(t, out)
+
+	msgs := make([]string, len(logs))
+	for i := range logs {
+		msgs[i] = logs[i]["msg"].(string) //nolint:forcetypeassert
+	}
+
+	assert.Equal(t, []string{
+		"test-warn",
+		"Suppressed 2 similar messages",
+		"test-info",
+	}, msgs)
+
+	assert.NoError(t, logger.(interface{ Close() error }).Close())
+}
+
+func TestNew_SamplingPrefixRule(t *testing.T) {
+	t.Parallel()
+
+	out := filepath.Join(t.TempDir(), "out.log")
+
+	logger, err := clogger.New(clogger.Config{
+		Out:    out,
+		Err:    out,
+		Format: clogger.FormatJSON,
+		Sampling: []clogger.SamplingRule{
+			{First: 1, IntervalSeconds: 60},
+			{Prefix: "[http]", First: 2, IntervalSeconds: 60},
+		},
+	}, nil)
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		logger.WithPrefix("http/router").Info("test-http")
+		logger.Info("[csql] test-csql")
+	}
+
+	logger.(interface{ Flush() }).Flush()
+
+	logs := readJSONLogs(t, out)
+
+	msgs := make([]string, len(logs))
+	for i := range logs {
+		msgs[i] = logs[i]["msg"].(string) //nolint:forcetypeassert
+	}
+
+	assert.Equal(t, []string{
+		"[http/router] test-http",
+		"[csql] test-csql",
+		"[http/router] test-http",
+	}, msgs)
+}
+
+func TestNew_SamplingEviction(t *testing.T) {
+	t.Parallel()
+
+	const distinctMsgs = 4096
+
+	out := filepath.Join(t.TempDir(), "out.log")
+
+	logger, err := clogger.New(clogger.Config{
+		Out:    out,
+		Err:    out,
+		Format: clogger.FormatJSON,
+		Sampling: []clogger.SamplingRule{
+			{First: 1, IntervalSeconds: 3600},
+		},
+	}, nil)
+	assert.NoError(t, err)
+
+	for i := 0; i < distinctMsgs; i++ {
+		logger.Info("test-msg-" + strconv.Itoa(i))
+		logger.Info("test-msg-" + strconv.Itoa(i))
+	}
+
+	// All the counters are active, so they are evicted to make room for the new message
+	logger.Info("test-new-msg")
+
+	assert.NoError(t, logger.(interface{ Close() error }).Close())
+
+	var summaries int
+
+	for _, log := range readJSONLogs(t, out) {
+		if log["msg"] == "Suppressed 1 similar messages" {
+			summaries++
+		}
+	}
+
+	assert.Equal(t, distinctMsgs, summaries)
+}
+
+func TestLoadConfig_InvalidSampling(t *testing.T) {
+	t.Parallel()
+
+	_, err := clogger.LoadConfig(testConfigLoader{Sampling: []clogger.SamplingRule{{Level: "loud", First: 1}}})
+	assert.Error(t, err)
+
+	_, err = clogger.LoadConfig(testConfigLoader{Sampling: []clogger.SamplingRule{{Level: "warn"}}})
+	assert.Error(t, err)
+}
+
+func readJSONLogs(t *testing.T, path string) []map[string]any {
+	t.Helper()
+
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	logs := make([]map[string]any, len(lines))
+
+	for i := range lines {
+		assert.NoError(t, json.Unmarshal([]byte(lines[i]), &logs[i]))
+	}
+
+	return logs
+}
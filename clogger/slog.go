@@ -0,0 +1,317 @@
+package clogger
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"sort"
+	"time"
+)
+
+// NewSlogHandler returns a slog.Handler that writes records using the given Logger. Attributes are written as tags,
+// groups are written as nested tags, and a record attribute named "error" or "err" that holds an error is passed as
+// the log's error. The tags from the registered ContextExtractors are added using the context passed to slog (ex.
+// slog.InfoContext), and the caller written by the Logger is the code that called slog.
+func NewSlogHandler(logger Logger) slog.Handler {
+	return &slogHandler{logger: logger}
+}
+
+// NewFromSlog returns a Logger that writes logs using the given slog.Handler. Tags are written as attributes, tags
+// that hold a map[string]any are written as groups, and errors are written as an attribute named "error".
+func NewFromSlog(handler slog.Handler) Logger {
+	return &slogLogger{handler: handler}
+}
+
+// SetSlogDefault makes the given Logger the default logger for the log/slog package. Since log/slog also redirects
+// the standard log package, logs from third-party libraries using either of them are written using the Logger.
+func SetSlogDefault(logger Logger) {
+	slog.SetDefault(slog.New(NewSlogHandler(logger)))
+}
+
+// levelEnabler is implemented by loggers that can report whether logs at a level would be written.
+type levelEnabler interface {
+	enabled(lvl Level) bool
+}
+
+type slogHandler struct {
+	logger Logger
+	groups []slogGroup
+}
+
+// slogGroup is a group opened using WithGroup along with the attributes added to it using WithAttrs.
+type slogGroup struct {
+	name  string
+	attrs []slog.Attr
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	if e, ok := h.logger.(levelEnabler); ok {
+		return e.enabled(levelFromSlog(level))
+	}
+
+	return true
+}
+
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	var (
+		attrs = make([]slog.Attr, 0, record.NumAttrs())
+		err   error
+	)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		if err == nil && (attr.Key == "error" || attr.Key == "err") {
+			if attrErr, ok := attr.Value.Resolve().Any().(error); ok {
+				err = attrErr
+				return true
+			}
+		}
+
+		attrs = append(attrs, attr)
+
+		return true
+	})
+
+	tags := attrsToTags(attrs)
+
+	// Nest the tags under each of the open groups starting with the innermost one
+	for i := len(h.groups) - 1; i >= 0; i-- {
+		groupTags := mergeTags(attrsToTags(h.groups[i].attrs), tags)
+
+		tags = make(map[string]any)
+		if len(groupTags) > 0 {
+			tags[h.groups[i].name] = groupTags
+		}
+	}
+
+	logger := h.logger
+
+	// The tags from the context are not nested under the groups, the same as the tags added by FromContext
+	if ctx != nil {
+		if ctxTags := extractContextTags(ctx); len(ctxTags) > 0 {
+			logger = logger.WithTags(ctxTags)
+		}
+	}
+
+	if len(tags) > 0 {
+		logger = logger.WithTags(tags)
+	}
+
+	lvl := levelFromSlog(record.Level)
+
+	// Debug and info logs do not have an error so it is written as a tag instead
+	if lvl < LevelWarn && err != nil {
+		logger = logger.WithTags(map[string]any{"error": err.Error()})
+		err = nil
+	}
+
+	// The caller is the code that called slog rather than the handler
+	if cl, ok := logger.(callerLogger); ok && record.PC != 0 {
+		cl.logWithCaller(lvl, record.Message, err, record.PC)
+		return nil
+	}
+
+	switch lvl {
+	case LevelDebug, LevelInfo:
+		if lvl == LevelDebug {
+			logger.Debug(record.Message)
+		} else {
+			logger.Info(record.Message)
+		}
+	case LevelWarn:
+		logger.Warn(record.Message, err)
+	case LevelError:
+		fallthrough
+	default:
+		logger.Error(record.Message, err)
+	}
+
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(h.groups) == 0 {
+		return &slogHandler{
+			logger: h.logger.WithTags(attrsToTags(attrs)),
+		}
+	}
+
+	groups := make([]slogGroup, len(h.groups))
+	copy(groups, h.groups)
+
+	last := &groups[len(groups)-1]
+	last.attrs = append(append(make([]slog.Attr, 0, len(last.attrs)+len(attrs)), last.attrs...), attrs...)
+
+	return &slogHandler{
+		logger: h.logger,
+		groups: groups,
+	}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	groups := make([]slogGroup, len(h.groups), len(h.groups)+1)
+	copy(groups, h.groups)
+
+	return &slogHandler{
+		logger: h.logger,
+		groups: append(groups, slogGroup{name: name}),
+	}
+}
+
+type slogLogger struct {
+	handler slog.Handler
+	prefix  string
+}
+
+func (l *slogLogger) WithTags(tags map[string]any) Logger {
+	return &slogLogger{
+		handler: l.handler.WithAttrs(tagsToAttrs(tags)),
+		prefix:  l.prefix,
+	}
+}
+
+func (l *slogLogger) WithPrefix(prefix string) Logger {
+	return &slogLogger{
+		handler: l.handler,
+		prefix:  prefix,
+	}
+}
+
+func (l *slogLogger) Debug(msg string) {
+	l.log(LevelDebug, msg, nil)
+}
+
+func (l *slogLogger) Info(msg string) {
+	l.log(LevelInfo, msg, nil)
+}
+
+func (l *slogLogger) Warn(msg string, err error) {
+	l.log(LevelWarn, msg, err)
+}
+
+func (l *slogLogger) Error(msg string, err error) {
+	l.log(LevelError, msg, err)
+}
+
+func (l *slogLogger) enabled(lvl Level) bool {
+	return l.handler.Enabled(context.Background(), lvl.slogLevel())
+}
+
+func (l *slogLogger) log(lvl Level, msg string, err error) {
+	const skip = 3 // runtime.Callers, log, and the Logger method
+
+	ctx := context.Background()
+
+	if !l.handler.Enabled(ctx, lvl.slogLevel()) {
+		return
+	}
+
+	if l.prefix != "" {
+		msg = "[" + l.prefix + "] " + msg
+	}
+
+	var pcs [1]uintptr
+	runtime.Callers(skip, pcs[:])
+
+	record := slog.NewRecord(time.Now(), lvl.slogLevel(), msg, pcs[0])
+	if err != nil {
+		record.AddAttrs(slog.Any("error", err))
+	}
+
+	_ = l.handler.Handle(ctx, record)
+}
+
+func levelFromSlog(level slog.Level) Level {
+	switch {
+	case level < slog.LevelInfo:
+		return LevelDebug
+	case level < slog.LevelWarn:
+		return LevelInfo
+	case level < slog.LevelError:
+		return LevelWarn
+	default:
+		return LevelError
+	}
+}
+
+func (l Level) slogLevel() slog.Level {
+	switch l {
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelInfo:
+		return slog.LevelInfo
+	case LevelWarn:
+		return slog.LevelWarn
+	case LevelError:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// attrsToTags converts slog attributes into tags. Groups are converted into nested tags and groups without a key are
+// inlined as defined by slog.Handler.
+func attrsToTags(attrs []slog.Attr) map[string]any {
+	tags := make(map[string]any, len(attrs))
+
+	for _, attr := range attrs {
+		attr.Value = attr.Value.Resolve()
+
+		if attr.Equal(slog.Attr{}) {
+			continue
+		}
+
+		if attr.Value.Kind() != slog.KindGroup {
+			if err, ok := attr.Value.Any().(error); ok {
+				tags[attr.Key] = err.Error()
+				continue
+			}
+
+			tags[attr.Key] = attr.Value.Any()
+
+			continue
+		}
+
+		groupTags := attrsToTags(attr.Value.Group())
+		if len(groupTags) == 0 {
+			continue
+		}
+
+		if attr.Key == "" {
+			for k, v := range groupTags {
+				tags[k] = v
+			}
+
+			continue
+		}
+
+		tags[attr.Key] = groupTags
+	}
+
+	return tags
+}
+
+// tagsToAttrs converts tags into slog attributes. Nested tags are converted into groups.
+func tagsToAttrs(tags map[string]any) []slog.Attr {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	attrs := make([]slog.Attr, 0, len(tags))
+	for _, k := range keys {
+		if nested, ok := tags[k].(map[string]any); ok {
+			attrs = append(attrs, slog.Attr{Key: k, Value: slog.GroupValue(tagsToAttrs(nested)...)})
+			continue
+		}
+
+		attrs = append(attrs, slog.Any(k, tags[k]))
+	}
+
+	return attrs
+}
@@ -0,0 +1,79 @@
+package clogger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/gocopper/copper/clogger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSlogHandler(t *testing.T) {
+	t.Parallel()
+
+	var (
+		buf    bytes.Buffer
+		logged map[string]any
+	)
+
+	logger := slog.New(clogger.NewSlogHandler(clogger.NewWithWriters(&buf, &buf, clogger.FormatJSON, nil, nil, nil)))
+
+	logger.With("service", "api").
+		WithGroup("req").
+		With("method", "GET").
+		Error("request failed", "path", "/users", "error", errors.New("test-err")) //nolint:goerr113
+
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &logged))
+	assert.Equal(t, "ERROR", logged["level"])
+	assert.Equal(t, "request failed", logged["msg"])
+	assert.Equal(t, map[string]any{
+		"service": "api",
+		"req": map[string]any{
+			"method": "GET",
+			"path":   "/users",
+		},
+	}, logged["tags"])
+	assert.Contains(t, buf.String(), "test-err")
+}
+
+func TestNewSlogHandler_Enabled(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := slog.New(clogger.NewSlogHandler(clogger.NewWithWriters(&buf, &buf, clogger.FormatPlain, nil,
+		map[clogger.Level]bool{clogger.LevelError: true}, nil)))
+
+	logger.Info("test-info")
+	logger.Error("test-error")
+
+	assert.NotContains(t, buf.String(), "test-info")
+	assert.Contains(t, buf.String(), "test-error")
+}
+
+func TestNewFromSlog(t *testing.T) {
+	t.Parallel()
+
+	var (
+		buf    bytes.Buffer
+		logged map[string]any
+	)
+
+	logger := clogger.NewFromSlog(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	logger.
+		WithPrefix("worker").
+		WithTags(map[string]any{
+			"job": map[string]any{"id": 1},
+		}).
+		Warn("job failed", errors.New("test-err")) //nolint:goerr113
+
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &logged))
+	assert.Equal(t, "WARN", logged["level"])
+	assert.Equal(t, "[worker] job failed", logged["msg"])
+	assert.Equal(t, map[string]any{"id": float64(1)}, logged["job"])
+	assert.Equal(t, "test-err", logged["error"])
+}
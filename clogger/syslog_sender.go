@@ -0,0 +1,188 @@
+package clogger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gocopper/copper/cerrors"
+)
+
+// DefaultSyslogAddr is the local syslog socket used if no address is configured
+const DefaultSyslogAddr = "/dev/log"
+
+// syslogFacilityUser is the "user-level messages" syslog facility
+const syslogFacilityUser = 1
+
+// NewSyslogSender creates a BatchSender that writes records to a syslog server. The network can be "udp", "tcp",
+// "unix", or "unixgram". If it is empty, the local syslog socket at addr (default /dev/log) is used. The tag
+// identifies the app in the logs and defaults to the name of the executable.
+func NewSyslogSender(network, addr, tag string) *SyslogSender {
+	if addr == "" && (network == "" || strings.HasPrefix(network, "unix")) {
+		addr = DefaultSyslogAddr
+	}
+
+	if tag == "" {
+		tag = filepath.Base(os.Args[0])
+	}
+
+	return &SyslogSender{
+		network: network,
+		addr:    addr,
+		tag:     tag,
+	}
+}
+
+// SyslogSender is a BatchSender that writes each record as a syslog message. Tags and errors are appended to the
+// message as key=value pairs. The connection is re-established if writing to it fails.
+type SyslogSender struct {
+	network string
+	addr    string
+	tag     string
+
+	mu          sync.Mutex
+	conn        net.Conn
+	connNetwork string
+	hostname    string
+}
+
+// Send writes the records to the syslog connection, connecting first if needed. If writing fails, the records that
+// were already written are not sent again when the batch is retried.
+func (s *SyslogSender) Send(ctx context.Context, records []Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		err := s.connect(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = s.conn.SetWriteDeadline(deadline)
+	}
+
+	for i, r := range records {
+		_, err := s.conn.Write(s.format(r))
+		if err != nil {
+			_ = s.conn.Close()
+			s.conn = nil
+
+			return partialError{sent: i, err: cerrors.New(err, "failed to write to syslog", map[string]any{
+				"addr": s.addr,
+			})}
+		}
+	}
+
+	return nil
+}
+
+// Close closes the connection to the syslog server.
+func (s *SyslogSender) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+
+	err := s.conn.Close()
+	s.conn = nil
+
+	return err //nolint:wrapcheck
+}
+
+func (s *SyslogSender) connect(ctx context.Context) error {
+	var (
+		dialer   net.Dialer
+		networks = []string{s.network}
+		err      error
+	)
+
+	// The local syslog socket may be either a datagram or a stream socket
+	if s.network == "" {
+		networks = []string{"unixgram", "unix"}
+	}
+
+	for _, network := range networks {
+		s.conn, err = dialer.DialContext(ctx, network, s.addr)
+		if err == nil {
+			s.connNetwork = network
+			break
+		}
+	}
+
+	if err != nil {
+		return cerrors.New(err, "failed to connect to syslog", map[string]any{
+			"network": s.network,
+			"addr":    s.addr,
+		})
+	}
+
+	s.hostname, _ = os.Hostname()
+
+	return nil
+}
+
+// format returns the record as a syslog message. Local sockets use the format expected by the local syslog daemon,
+// which adds the hostname itself.
+func (s *SyslogSender) format(r Record) []byte {
+	var (
+		buf   bytes.Buffer
+		pri   = syslogFacilityUser*8 + syslogSeverity(r.Level)
+		local = s.network == "" || strings.HasPrefix(s.network, "unix")
+	)
+
+	if local {
+		fmt.Fprintf(&buf, "<%d>%s %s[%d]: ", pri, r.Time.Format(time.Stamp), s.tag, os.Getpid())
+	} else {
+		fmt.Fprintf(&buf, "<%d>%s %s %s[%d]: ", pri, r.Time.Format(time.RFC3339), s.hostname, s.tag, os.Getpid())
+	}
+
+	buf.WriteString(r.Msg)
+
+	var kvs bytes.Buffer
+
+	if r.Err != nil {
+		writeLogfmtPair(&kvs, "error", r.Err.Error())
+	}
+
+	for _, kv := range flattenTags("", r.Tags) {
+		writeLogfmtPair(&kvs, kv.key, kv.val)
+	}
+
+	if kvs.Len() > 0 {
+		buf.WriteByte(' ')
+		buf.Write(kvs.Bytes())
+	}
+
+	// Stream sockets need the messages to be delimited
+	if s.connNetwork != "udp" && s.connNetwork != "unixgram" {
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes()
+}
+
+// syslogSeverity returns the syslog severity of the level.
+func syslogSeverity(lvl Level) int {
+	switch lvl {
+	case LevelDebug:
+		return 7
+	case LevelInfo:
+		return 6
+	case LevelWarn:
+		return 4
+	case LevelError:
+		return 3
+	default:
+		return 5
+	}
+}
@@ -18,21 +18,11 @@ func mergeTags(t1, t2 map[string]any) map[string]any {
 	return merged
 }
 
-func tagsToKVs(tags map[string]any) []any {
-	const TagsToKVsMultiplier = 2
-
-	kvs := make([]any, 0, len(tags)*TagsToKVsMultiplier)
-	for k, v := range tags {
-		kvs = append(kvs, k, v)
-	}
-	return kvs
-}
-
 func formatToZapEncoding(f Format) string {
 	switch f {
 	case FormatJSON:
 		return "json"
-	case FormatPlain:
+	case FormatPlain, FormatConsole, FormatLogfmt:
 		return "console"
 	default:
 		return "console"
@@ -0,0 +1,239 @@
+package clogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"regexp"
+
+	"github.com/gocopper/copper/cerrors"
+)
+
+// Built-in value patterns supported by Config.RedactPatterns
+const (
+	RedactPatternJWT        = "jwt"
+	RedactPatternBearer     = "bearer"
+	RedactPatternCreditCard = "credit_card"
+	RedactPatternEmail      = "email"
+)
+
+// valueRule replaces the sensitive values matched by its regex. If validate is set, only matches that it accepts
+// are replaced (ex. card numbers that pass the Luhn check).
+type valueRule struct {
+	re       *regexp.Regexp
+	repl     string
+	validate func(match string) bool
+}
+
+// valueRedactor redacts sensitive values (ex. emails) found anywhere in messages, tag values, and error strings.
+// A nil *valueRedactor does not redact anything.
+type valueRedactor struct {
+	rules []valueRule
+}
+
+func newValueRedactor(patterns, regexes []string) (*valueRedactor, error) {
+	if len(patterns) == 0 && len(regexes) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	r := &valueRedactor{
+		rules: make([]valueRule, 0, len(patterns)+len(regexes)),
+	}
+
+	for _, pattern := range patterns {
+		rule, ok := builtinValueRule(pattern)
+		if !ok {
+			return nil, cerrors.New(nil, "unknown redact pattern", map[string]any{
+				"pattern": pattern,
+			})
+		}
+
+		r.rules = append(r.rules, rule)
+	}
+
+	for _, expr := range regexes {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, cerrors.New(err, "invalid redact regex", map[string]any{
+				"regex": expr,
+			})
+		}
+
+		r.rules = append(r.rules, valueRule{re: re, repl: redactedValue})
+	}
+
+	return r, nil
+}
+
+func builtinValueRule(pattern string) (valueRule, bool) {
+	switch pattern {
+	case RedactPatternJWT:
+		return valueRule{
+			re:   regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+			repl: redactedValue,
+		}, true
+	case RedactPatternBearer:
+		return valueRule{
+			re:   regexp.MustCompile(`(?i)(bearer)\s+[A-Za-z0-9\-._~+/]+=*`),
+			repl: "${1} " + redactedValue,
+		}, true
+	case RedactPatternCreditCard:
+		return valueRule{
+			re:       regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`),
+			repl:     redactedValue,
+			validate: luhnValid,
+		}, true
+	case RedactPatternEmail:
+		return valueRule{
+			re:   regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+			repl: redactedValue,
+		}, true
+	default:
+		return valueRule{}, false
+	}
+}
+
+// String returns s with all of the sensitive values replaced.
+func (r *valueRedactor) String(s string) string {
+	if r == nil {
+		return s
+	}
+
+	for _, rule := range r.rules {
+		if rule.validate == nil {
+			s = rule.re.ReplaceAllString(s, rule.repl)
+			continue
+		}
+
+		s = rule.re.ReplaceAllStringFunc(s, func(match string) string {
+			if !rule.validate(match) {
+				return match
+			}
+
+			return rule.re.ReplaceAllString(match, rule.repl)
+		})
+	}
+
+	return s
+}
+
+// Value returns a copy of v with the sensitive values in all of its strings replaced. It supports the values
+// produced by decoding JSON (maps, slices, and scalars).
+func (r *valueRedactor) Value(v any) any {
+	if r == nil {
+		return v
+	}
+
+	switch val := v.(type) {
+	case string:
+		return r.String(val)
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, item := range val {
+			out[k] = r.Value(item)
+		}
+
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = r.Value(item)
+		}
+
+		return out
+	default:
+		return v
+	}
+}
+
+// Tags returns a copy of the tags with the sensitive values in them replaced. Tags are converted to their JSON
+// representation first so that values nested in structs are redacted as well.
+func (r *valueRedactor) Tags(tags map[string]any) map[string]any {
+	if r == nil || len(tags) == 0 {
+		return tags
+	}
+
+	out, err := redactJSONObject(tags, nil)
+	if err != nil {
+		return map[string]any{
+			"tags": cerrors.New(err, "tag redaction failed", nil).Error(),
+		}
+	}
+
+	return r.Value(out).(map[string]any) //nolint:forcetypeassert
+}
+
+// RawJSON returns a copy of the encoded JSON value with the sensitive values in its strings replaced.
+func (r *valueRedactor) RawJSON(in json.RawMessage) (json.RawMessage, error) {
+	if r == nil {
+		return in, nil
+	}
+
+	var v any
+
+	dec := json.NewDecoder(bytes.NewReader(in))
+	dec.UseNumber()
+
+	err := dec.Decode(&v)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	var b bytes.Buffer
+
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+
+	err = enc.Encode(r.Value(v))
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return bytes.TrimSuffix(b.Bytes(), []byte("\n")), nil
+}
+
+// redactingWriter redacts sensitive values in each line before writing it. It is used by the text formats where
+// the entire line can be redacted at once.
+type redactingWriter struct {
+	w io.Writer
+	r *valueRedactor
+}
+
+func (w redactingWriter) Write(p []byte) (int, error) {
+	_, err := w.w.Write([]byte(w.r.String(string(p))))
+	if err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+
+	return len(p), nil
+}
+
+// luhnValid returns true if the digits in s pass the Luhn checksum used by card numbers.
+func luhnValid(s string) bool {
+	var (
+		sum    int
+		double bool
+		digits int
+	)
+
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+
+		sum += d
+		double = !double
+		digits++
+	}
+
+	return digits >= 13 && sum%10 == 0
+}
@@ -0,0 +1,329 @@
+package clogger
+
+import (
+	"time"
+
+	"github.com/gocopper/copper/cerrors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// zapLogger is a Logger backed by zap. It has the same level, prefix, tag, redaction, and hook semantics as
+// LoggerImpl but encodes the tags once in WithTags instead of on every log.
+type zapLogger struct {
+	zap           *zap.Logger
+	base          *zap.Logger
+	tags          map[string]any
+	format        Format
+	redactFields  []string
+	valueRedactor *valueRedactor
+	prefix        string
+	levelFilter   map[Level]bool
+	levels        *Levels
+	caller        bool
+	errorStack    bool
+	sampler       *sampler
+	hooks         []Hook
+	outputs       *outputs
+}
+
+func newZapLogger(config Config, levels *Levels, hooks []Hook) (*zapLogger, error) {
+	levelFilter, err := config.levelFilter()
+	if err != nil {
+		return nil, cerrors.New(err, "invalid level filter", nil)
+	}
+
+	if config.Format == FormatLogfmt {
+		return nil, cerrors.New(nil, "logfmt format is not supported by the zap backend", nil)
+	}
+
+	valueRedactor, err := newValueRedactor(config.RedactPatterns, config.RedactRegexes)
+	if err != nil {
+		return nil, cerrors.New(err, "invalid value redaction", nil)
+	}
+
+	sampler, err := newSampler(config.Sampling)
+	if err != nil {
+		return nil, cerrors.New(err, "invalid sampling", nil)
+	}
+
+	o, err := openOutputs(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		encoder = newZapEncoder(config.Format)
+		out     = zapcore.Lock(zapcore.AddSync(o.out))
+		core    zapcore.Core
+	)
+
+	// Levels are filtered before logs reach zap so that prefix levels can be applied
+	if o.out == o.err {
+		core = zapcore.NewCore(encoder, out, zapcore.DebugLevel)
+	} else {
+		core = zapcore.NewTee(
+			zapcore.NewCore(encoder, out, zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
+				return lvl < zapcore.WarnLevel
+			})),
+			zapcore.NewCore(encoder, zapcore.Lock(zapcore.AddSync(o.err)), zapcore.WarnLevel),
+		)
+	}
+
+	base := zap.New(core)
+
+	l := &zapLogger{
+		base:          base,
+		tags:          make(map[string]any),
+		format:        config.Format,
+		redactFields:  expandRedactedFields(config.RedactFields),
+		valueRedactor: valueRedactor,
+		levelFilter:   levelFilter,
+		levels:        levels,
+		caller:        config.Caller,
+		errorStack:    config.ErrorStack,
+		sampler:       sampler,
+		hooks:         o.withHooks(hooks, expandRedactedFields(config.RedactFields), valueRedactor),
+		outputs:       o,
+	}
+
+	l.zap = l.withTagsField(base)
+
+	return l, nil
+}
+
+// newZapEncoder returns zap's JSON encoder for the json format and its console encoder for the other formats. The
+// console encoder separates the time, level, message, and fields with tabs, so the plain and console layouts differ
+// from the ones written by LoggerImpl for the same config.
+func newZapEncoder(format Format) zapcore.Encoder {
+	config := zapcore.EncoderConfig{
+		TimeKey:        "ts",
+		LevelKey:       "level",
+		MessageKey:     "msg",
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeLevel:    zapcore.CapitalLevelEncoder,
+		EncodeTime:     zapcore.TimeEncoderOfLayout(time.RFC3339),
+		EncodeDuration: zapcore.StringDurationEncoder,
+	}
+
+	if formatToZapEncoding(format) == "json" {
+		return zapcore.NewJSONEncoder(config)
+	}
+
+	if format == FormatConsole {
+		config.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	}
+
+	return zapcore.NewConsoleEncoder(config)
+}
+
+func (l *zapLogger) WithTags(tags map[string]any) Logger {
+	logger := &zapLogger{
+		base:          l.base,
+		tags:          mergeTags(l.tags, tags),
+		format:        l.format,
+		redactFields:  l.redactFields,
+		valueRedactor: l.valueRedactor,
+		prefix:        l.prefix,
+		levelFilter:   l.levelFilter,
+		levels:        l.levels,
+		caller:        l.caller,
+		errorStack:    l.errorStack,
+		sampler:       l.sampler,
+		hooks:         l.hooks,
+		outputs:       l.outputs,
+	}
+
+	logger.zap = logger.withTagsField(l.base)
+
+	return logger
+}
+
+func (l *zapLogger) WithPrefix(prefix string) Logger {
+	return &zapLogger{
+		zap:           l.zap,
+		base:          l.base,
+		tags:          l.tags,
+		format:        l.format,
+		redactFields:  l.redactFields,
+		valueRedactor: l.valueRedactor,
+		prefix:        prefix,
+		levelFilter:   l.levelFilter,
+		levels:        l.levels,
+		caller:        l.caller,
+		errorStack:    l.errorStack,
+		sampler:       l.sampler,
+		hooks:         l.hooks,
+		outputs:       l.outputs,
+	}
+}
+
+func (l *zapLogger) Debug(msg string) {
+	l.log(LevelDebug, msg, nil, 0)
+}
+
+func (l *zapLogger) Info(msg string) {
+	l.log(LevelInfo, msg, nil, 0)
+}
+
+func (l *zapLogger) Warn(msg string, err error) {
+	l.log(LevelWarn, msg, err, 0)
+}
+
+func (l *zapLogger) Error(msg string, err error) {
+	l.log(LevelError, msg, err, 0)
+}
+
+// Flush waits until the logs buffered in async mode have been written.
+func (l *zapLogger) Flush() {
+	_ = l.zap.Sync()
+	l.outputs.Flush()
+}
+
+// DroppedLines returns the number of logs dropped in async mode because the buffer was full.
+func (l *zapLogger) DroppedLines() uint64 {
+	return l.outputs.Dropped()
+}
+
+// Close summarizes the messages suppressed by sampling, writes the logs buffered in async mode, and closes the log
+// files opened by the logger.
+func (l *zapLogger) Close() error {
+	for _, s := range l.sampler.Flush() {
+		l.logSuppressed(s)
+	}
+
+	_ = l.zap.Sync()
+	return l.outputs.Close()
+}
+
+// Levels returns the levels used by the logger, which can be changed at runtime.
+func (l *zapLogger) Levels() *Levels {
+	return l.levels
+}
+
+func (l *zapLogger) enabled(lvl Level) bool {
+	return levelEnabled(l.levelFilter, l.levels, l.prefix, lvl)
+}
+
+func (l *zapLogger) logWithCaller(lvl Level, msg string, err error, pc uintptr) {
+	l.log(lvl, msg, err, pc)
+}
+
+// log writes the log if its level is enabled and it is sampled. The caller is the code that called the Logger method
+// unless pc, the program counter of the code that wrote the log, is set.
+func (l *zapLogger) log(lvl Level, msg string, err error, pc uintptr) {
+	prefix := l.prefix
+	if prefix == "" {
+		prefix = msgPrefix(msg)
+	}
+
+	if !levelEnabled(l.levelFilter, l.levels, prefix, lvl) {
+		return
+	}
+
+	ok, suppressed := l.sampler.Sample(lvl, prefix, msg)
+	for _, s := range suppressed {
+		l.logSuppressed(s)
+	}
+
+	if !ok {
+		return
+	}
+
+	var caller *logCaller
+	if withStack := l.errorStack && lvl == LevelError; l.caller || withStack {
+		caller = captureCaller(withStack, pc)
+	}
+
+	l.write(lvl, msg, err, caller)
+}
+
+// logSuppressed logs a summary of the messages suppressed by sampling with the level and prefix they were logged with.
+func (l *zapLogger) logSuppressed(s suppressedSummary) {
+	logger := l.WithPrefix(s.prefix).WithTags(suppressedTags(s.msg, s.count)).(*zapLogger) //nolint:forcetypeassert
+
+	logger.write(s.level, suppressedMsg(s.count), nil, nil)
+}
+
+func (l *zapLogger) write(lvl Level, msg string, err error, caller *logCaller) {
+	logMsg := msg
+	if l.prefix != "" {
+		logMsg = "[" + l.prefix + "] " + msg
+	}
+
+	logMsg = l.valueRedactor.String(logMsg)
+
+	var fields []zap.Field
+	if err != nil {
+		fields = append(fields, l.errorField(err))
+	}
+
+	if caller != nil {
+		fields = append(fields, zap.String("caller", caller.String()))
+
+		if len(caller.stack) > 0 && l.format == FormatJSON {
+			fields = append(fields, zap.Reflect("stack", caller.stack))
+		} else if len(caller.stack) > 0 {
+			fields = append(fields, zap.String("stack", caller.stackString()))
+		}
+	}
+
+	switch lvl {
+	case LevelDebug:
+		l.zap.Debug(logMsg, fields...)
+	case LevelInfo:
+		l.zap.Info(logMsg, fields...)
+	case LevelWarn:
+		l.zap.Warn(logMsg, fields...)
+	case LevelError:
+		fallthrough
+	default:
+		l.zap.Error(logMsg, fields...)
+	}
+
+	for i := range l.hooks {
+		l.hooks[i].OnLog(lvl, msg, l.tags, err)
+	}
+}
+
+// withTagsField returns the base logger with the redacted tags added as a field. zap encodes the field once here
+// instead of on every log.
+func (l *zapLogger) withTagsField(base *zap.Logger) *zap.Logger {
+	if l.format == FormatJSON {
+		redactedTags, err := redactJSONObject(l.tags, l.redactFields)
+		if err != nil {
+			return base.With(zap.String("tags", cerrors.New(err, "tag redaction failed", nil).Error()))
+		}
+
+		return base.With(zap.Reflect("tags", l.valueRedactor.Value(redactedTags)))
+	}
+
+	if len(l.tags) == 0 {
+		return base
+	}
+
+	return base.With(zap.Any("tags", l.valueRedactor.Tags(redactTags(l.tags, l.redactFields))))
+}
+
+func (l *zapLogger) errorField(err error) zap.Field {
+	if l.format == FormatJSON {
+		redactedErr, redactErr := redactJSONValue(cerrors.ChainOf(err), l.redactFields)
+		if redactErr == nil {
+			redactedErr, redactErr = l.valueRedactor.RawJSON(redactedErr)
+		}
+
+		if redactErr != nil {
+			return zap.String("error", cerrors.New(redactErr, "error redaction failed", nil).Error())
+		}
+
+		return zap.Reflect("error", redactedErr)
+	}
+
+	if len(l.redactFields) > 0 {
+		err = cerrors.MapTags(err, func(errTags map[string]any) map[string]any {
+			return redactTags(errTags, l.redactFields)
+		})
+	}
+
+	return zap.String("error", l.valueRedactor.String(err.Error()))
+}
@@ -0,0 +1,83 @@
+package clogger_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gocopper/copper/clogger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_Zap(t *testing.T) {
+	t.Parallel()
+
+	var (
+		out    = filepath.Join(t.TempDir(), "out.log")
+		logged map[string]any
+		hook   = &testHook{}
+	)
+
+	logger, err := clogger.New(clogger.Config{
+		Out:          out,
+		Err:          out,
+		Format:       clogger.FormatJSON,
+		Backend:      clogger.BackendZap,
+		RedactFields: []string{"password"},
+	}, []clogger.Hook{hook})
+	assert.NoError(t, err)
+
+	logger.
+		WithPrefix("auth").
+		WithTags(map[string]any{"user": "test-user", "password": "test-password"}).
+		WithTags(map[string]any{"user": "test-user-2"}).
+		Error("test-msg", errors.New("test-err")) //nolint:goerr113
+
+	logger.Debug("test-debug")
+
+	closer, ok := logger.(interface{ Close() error })
+	assert.True(t, ok)
+	assert.NoError(t, closer.Close())
+
+	logs, err := os.ReadFile(out)
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(logs)), "\n")
+	assert.Len(t, lines, 2)
+
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &logged))
+	assert.Equal(t, "ERROR", logged["level"])
+	assert.Equal(t, "[auth] test-msg", logged["msg"])
+	assert.Equal(t, map[string]any{"user": "test-user-2", "password": "redacted"}, logged["tags"])
+	assert.Contains(t, lines[0], "test-err")
+	assert.NotContains(t, lines[0], "test-password")
+
+	assert.Equal(t, []string{"test-msg", "test-debug"}, hook.msgs)
+}
+
+type testHook struct {
+	msgs []string
+}
+
+func (h *testHook) OnLog(_ clogger.Level, msg string, _ map[string]any, _ error) {
+	h.msgs = append(h.msgs, msg)
+}
+
+func TestNew_ZapInvalidLevel(t *testing.T) {
+	t.Parallel()
+
+	logger, err := clogger.New(clogger.Config{Backend: clogger.BackendZap, Level: "verbose"}, nil)
+	assert.Error(t, err)
+	assert.Nil(t, logger)
+}
+
+func TestNew_InvalidBackend(t *testing.T) {
+	t.Parallel()
+
+	logger, err := clogger.New(clogger.Config{Backend: "unknown"}, nil)
+	assert.Error(t, err)
+	assert.Nil(t, logger)
+}
@@ -0,0 +1,3 @@
+// Package cmetricstest provides helpers to create metrics with an isolated prometheus registry in tests and to
+// assert on their values.
+package cmetricstest
@@ -0,0 +1,167 @@
+package cmetricstest
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gocopper/copper/clogger"
+	"github.com/gocopper/copper/cmetrics"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// TestingT is the subset of testing.TB used by the assertions.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// New creates Metrics for the metrics defined in the given params along with the prometheus registry that they are
+// registered with. Since each call uses its own registry, it is safe to use in parallel tests.
+func New(t testing.TB, p cmetrics.NewRegistryParams) (cmetrics.Metrics, *prometheus.Registry) {
+	t.Helper()
+
+	registry := prometheus.NewRegistry()
+
+	metrics, err := cmetrics.NewMetrics(cmetrics.NewRegistry(p), registry, clogger.NewNoop())
+	if err != nil {
+		t.Fatalf("failed to create metrics: %v", err)
+	}
+
+	return metrics, registry
+}
+
+// Value returns the value of the counter or gauge with the given name and labels. For histograms and summaries, the
+// sum of the observed values is returned. The second return value is false if the metric was not found.
+func Value(t TestingT, g prometheus.Gatherer, name string, labels map[string]string) (float64, bool) {
+	t.Helper()
+
+	m, ok := find(t, g, name, labels)
+	if !ok {
+		return 0, false
+	}
+
+	switch {
+	case m.GetCounter() != nil:
+		return m.GetCounter().GetValue(), true
+	case m.GetGauge() != nil:
+		return m.GetGauge().GetValue(), true
+	case m.GetHistogram() != nil:
+		return m.GetHistogram().GetSampleSum(), true
+	case m.GetSummary() != nil:
+		return m.GetSummary().GetSampleSum(), true
+	default:
+		return m.GetUntyped().GetValue(), true
+	}
+}
+
+// SampleCount returns the number of values observed by the histogram or summary with the given name and labels.
+// The second return value is false if the metric was not found.
+func SampleCount(t TestingT, g prometheus.Gatherer, name string, labels map[string]string) (uint64, bool) {
+	t.Helper()
+
+	m, ok := find(t, g, name, labels)
+	if !ok {
+		return 0, false
+	}
+
+	if m.GetHistogram() != nil {
+		return m.GetHistogram().GetSampleCount(), true
+	}
+
+	return m.GetSummary().GetSampleCount(), true
+}
+
+// AssertValue fails the test if the counter or gauge with the given name and labels does not have the expected
+// value.
+func AssertValue(t TestingT, g prometheus.Gatherer, name string, labels map[string]string, want float64) bool {
+	t.Helper()
+
+	got, ok := Value(t, g, name, labels)
+	if !ok {
+		t.Errorf("metric %s%s was not found", name, formatLabels(labels))
+		return false
+	}
+
+	if got != want {
+		t.Errorf("expected metric %s%s to be %v, got %v", name, formatLabels(labels), want, got)
+		return false
+	}
+
+	return true
+}
+
+// AssertSampleCount fails the test if the histogram or summary with the given name and labels did not observe the
+// expected number of values.
+func AssertSampleCount(t TestingT, g prometheus.Gatherer, name string, labels map[string]string, want uint64) bool {
+	t.Helper()
+
+	got, ok := SampleCount(t, g, name, labels)
+	if !ok {
+		t.Errorf("metric %s%s was not found", name, formatLabels(labels))
+		return false
+	}
+
+	if got != want {
+		t.Errorf("expected metric %s%s to have %d samples, got %d", name, formatLabels(labels), want, got)
+		return false
+	}
+
+	return true
+}
+
+// find returns the metric with the given name whose labels are exactly the given labels.
+func find(t TestingT, g prometheus.Gatherer, name string, labels map[string]string) (*dto.Metric, bool) {
+	t.Helper()
+
+	families, err := g.Gather()
+	if err != nil {
+		t.Errorf("failed to gather metrics: %v", err)
+		return nil, false
+	}
+
+	for _, f := range families {
+		if f.GetName() != name {
+			continue
+		}
+
+		for _, m := range f.GetMetric() {
+			if labelsEqual(m.GetLabel(), labels) {
+				return m, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func labelsEqual(pairs []*dto.LabelPair, labels map[string]string) bool {
+	if len(pairs) != len(labels) {
+		return false
+	}
+
+	for _, pair := range pairs {
+		v, ok := labels[pair.GetName()]
+		if !ok || v != pair.GetValue() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+`"`+v+`"`)
+	}
+
+	sort.Strings(pairs)
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
@@ -0,0 +1,130 @@
+package cmetrics
+
+import (
+	"github.com/gocopper/copper/cconfig"
+	"github.com/gocopper/copper/cerrors"
+)
+
+// Exporters supported by the cmetrics config
+const (
+	ExporterPrometheus = "prometheus"
+	ExporterOTLP       = "otlp"
+)
+
+// Backends supported by NewConfiguredMetrics
+const (
+	BackendPrometheus = "prometheus"
+	BackendStatsD     = "statsd"
+)
+
+// Histogram types supported by the statsd backend
+const (
+	StatsDHistogramTiming       = "timing"
+	StatsDHistogramDistribution = "distribution"
+)
+
+// LoadConfig loads Config from app's config
+func LoadConfig(appConfig cconfig.Loader) (Config, error) {
+	var config Config
+
+	err := appConfig.Load("cmetrics", &config)
+	if err != nil {
+		return Config{}, cerrors.New(err, "failed to load cmetrics config", nil)
+	}
+
+	if config.Exporter != "" && config.Exporter != ExporterPrometheus && config.Exporter != ExporterOTLP {
+		return Config{}, cerrors.New(nil, "invalid exporter in cmetrics config", map[string]any{
+			"exporter": config.Exporter,
+		})
+	}
+
+	if config.Backend != "" && config.Backend != BackendPrometheus && config.Backend != BackendStatsD {
+		return Config{}, cerrors.New(nil, "invalid backend in cmetrics config", map[string]any{
+			"backend": config.Backend,
+		})
+	}
+
+	switch config.StatsDNetwork {
+	case "", "udp", "udp4", "udp6", "unixgram":
+	default:
+		return Config{}, cerrors.New(nil, "invalid statsd network in cmetrics config", map[string]any{
+			"network": config.StatsDNetwork,
+		})
+	}
+
+	if config.StatsDHistogramType != "" && config.StatsDHistogramType != StatsDHistogramTiming &&
+		config.StatsDHistogramType != StatsDHistogramDistribution {
+		return Config{}, cerrors.New(nil, "invalid statsd histogram type in cmetrics config", map[string]any{
+			"histogram_type": config.StatsDHistogramType,
+		})
+	}
+
+	return config, nil
+}
+
+// Config holds the params needed to configure the prometheus registry, Router, Server, and OTLPExporter
+type Config struct {
+	// Path is where the metrics are served in the Prometheus exposition format
+	Path string `toml:"path" default:"/metrics"`
+
+	// Port serves the metrics on a separate internal server instead of the app's http server
+	Port uint `toml:"port"`
+
+	// BasicAuthUsername and BasicAuthPassword protect the metrics endpoint with basic auth if they are set
+	BasicAuthUsername string `toml:"basic_auth_username"`
+	BasicAuthPassword string `toml:"basic_auth_password"`
+
+	// DefaultCollectors adds the Go runtime and process metrics to the app's prometheus registry
+	DefaultCollectors bool `toml:"default_collectors" default:"true"`
+
+	// Exporter is how the metrics are exported - "prometheus" (default) serves them to be scraped and "otlp" pushes
+	// them to OTLPEndpoint every OTLPIntervalSeconds
+	Exporter string `toml:"exporter" default:"prometheus"`
+
+	// OTLPEndpoint is the metrics endpoint of an OpenTelemetry collector using OTLP/HTTP. The headers are added to
+	// every request (ex. for authorization). Each request is canceled if it takes longer than OTLPTimeoutSeconds.
+	OTLPEndpoint        string            `toml:"otlp_endpoint" default:"http://localhost:4318/v1/metrics"`
+	OTLPHeaders         map[string]string `toml:"otlp_headers"`
+	OTLPIntervalSeconds uint              `toml:"otlp_interval_seconds" default:"60"`
+	OTLPTimeoutSeconds  uint              `toml:"otlp_timeout_seconds" default:"10"`
+
+	// ServiceName, ServiceVersion, and Environment are exported as the service.name, service.version, and
+	// deployment.environment resource attributes
+	ServiceName    string `toml:"service_name"`
+	ServiceVersion string `toml:"service_version"`
+	Environment    string `toml:"environment"`
+
+	// Backend is the implementation of Metrics - "prometheus" (default) records the metrics in the app's prometheus
+	// registry and "statsd" sends them to a StatsD agent instead. Typed metrics are recorded by the same
+	// backend.
+	Backend string `toml:"backend" default:"prometheus"`
+
+	// StatsDNetwork and StatsDAddr are where the statsd backend sends metrics - "udp" (default) with a host:port
+	// address or "unixgram" with a socket path
+	StatsDNetwork string `toml:"statsd_network" default:"udp"`
+	StatsDAddr    string `toml:"statsd_addr" default:"127.0.0.1:8125"`
+
+	// StatsDPrefix is prepended to the name of every metric sent to statsd (ex. "myapp."). Characters that are used by
+	// the StatsD format, in the prefix and in the metric names, are replaced with underscores.
+	StatsDPrefix string `toml:"statsd_prefix"`
+
+	// StatsDTags are added to every metric sent to statsd along with its labels using the DogStatsD tag format
+	StatsDTags map[string]string `toml:"statsd_tags"`
+
+	// StatsDFlushIntervalMS is how often the aggregated metrics are sent. They are batched into packets of up to
+	// StatsDMaxPacketSize bytes.
+	StatsDFlushIntervalMS uint `toml:"statsd_flush_interval_ms" default:"1000"`
+	StatsDMaxPacketSize   uint `toml:"statsd_max_packet_size" default:"1432"`
+
+	// StatsDHistogramType is how histograms and summaries are sent - "timing" (default) or "distribution". The
+	// observed values are sent as they are, without converting their unit.
+	StatsDHistogramType string `toml:"statsd_histogram_type" default:"timing"`
+
+	// StatsDMultiValue sends the values of a histogram or summary in a single line (ex. name:1:2|ms) instead of one
+	// line per value. It requires DogStatsD 1.1 or later.
+	StatsDMultiValue bool `toml:"statsd_multi_value"`
+
+	// StatsDGaugeExpiryFlushes is the number of flushes after which a gauge that has not been updated is no longer
+	// sent. An expired gauge starts at 0 when it is updated with GaugeAdd.
+	StatsDGaugeExpiryFlushes uint `toml:"statsd_gauge_expiry_flushes" default:"60"`
+}
@@ -2,30 +2,74 @@ package cmetrics
 
 import (
 	"github.com/gocopper/copper/cerrors"
+	"github.com/gocopper/copper/clifecycle"
 	"github.com/gocopper/copper/clogger"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 type Metrics interface {
 	CounterInc(name string, labels map[string]string)
+	CounterAdd(name string, labels map[string]string, value float64)
 	HistogramObserve(name string, labels map[string]string, value float64)
+	GaugeSet(name string, labels map[string]string, value float64)
+	GaugeAdd(name string, labels map[string]string, value float64)
+	SummaryObserve(name string, labels map[string]string, value float64)
 }
 
 type metrics struct {
 	counters   map[string]*prometheus.CounterVec
 	histograms map[string]*prometheus.HistogramVec
+	gauges     map[string]*prometheus.GaugeVec
+	summaries  map[string]*prometheus.SummaryVec
 
 	logger clogger.Logger
 }
 
-func NewMetrics(registry *Registry, logger clogger.Logger) (Metrics, error) {
+// NewConfiguredMetricsParams holds the params needed to create the Metrics configured by the backend config key
+type NewConfiguredMetricsParams struct {
+	Registry   *Registry
+	Prometheus *prometheus.Registry
+	Config     Config
+	Lifecycle  *clifecycle.Lifecycle
+	Logger     clogger.Logger
+}
+
+// NewConfiguredMetrics creates the Metrics configured by the backend config key. The typed metrics in the registry
+// are recorded by the same backend.
+func NewConfiguredMetrics(p NewConfiguredMetricsParams) (Metrics, error) {
+	switch p.Config.Backend {
+	case BackendStatsD:
+		metrics, err := NewStatsDMetrics(p.Config, p.Lifecycle, p.Logger)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, h := range p.Registry.Handles {
+			err = h.attach(metrics)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return metrics, nil
+	case BackendPrometheus, "":
+		return NewMetrics(p.Registry, p.Prometheus, p.Logger)
+	default:
+		return nil, cerrors.New(nil, "invalid backend", map[string]any{
+			"backend": p.Config.Backend,
+		})
+	}
+}
+
+// NewMetrics creates the metrics defined in the registry and registers them with the app's prometheus registry.
+func NewMetrics(registry *Registry, promRegistry *prometheus.Registry, logger clogger.Logger) (Metrics, error) {
 	countersByName := make(map[string]*prometheus.CounterVec)
 	for i := range registry.Counters {
 		countersByName[registry.Counters[i].Name] = prometheus.NewCounterVec(prometheus.CounterOpts{
 			Name: registry.Counters[i].Name,
 		}, registry.Counters[i].Labels)
 
-		err := prometheus.DefaultRegisterer.Register(countersByName[registry.Counters[i].Name])
+		err := promRegistry.Register(countersByName[registry.Counters[i].Name])
 		if err != nil {
 			return nil, cerrors.New(err, "failed to register counter metric", map[string]interface{}{
 				"name": registry.Counters[i].Name,
@@ -40,7 +84,7 @@ func NewMetrics(registry *Registry, logger clogger.Logger) (Metrics, error) {
 			Buckets: registry.Histograms[i].Buckets,
 		}, registry.Histograms[i].Labels)
 
-		err := prometheus.DefaultRegisterer.Register(histogramsByName[registry.Histograms[i].Name])
+		err := promRegistry.Register(histogramsByName[registry.Histograms[i].Name])
 		if err != nil {
 			return nil, cerrors.New(err, "failed to register histogram metric", map[string]interface{}{
 				"name": registry.Histograms[i].Name,
@@ -49,9 +93,45 @@ func NewMetrics(registry *Registry, logger clogger.Logger) (Metrics, error) {
 		}
 	}
 
+	gaugesByName := make(map[string]*prometheus.GaugeVec)
+	for i := range registry.Gauges {
+		gaugesByName[registry.Gauges[i].Name] = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: registry.Gauges[i].Name,
+		}, registry.Gauges[i].Labels)
+
+		err := promRegistry.Register(gaugesByName[registry.Gauges[i].Name])
+		if err != nil {
+			return nil, cerrors.New(err, "failed to register gauge metric", map[string]interface{}{
+				"name": registry.Gauges[i].Name,
+			})
+		}
+	}
+
+	summariesByName := make(map[string]*prometheus.SummaryVec)
+	for i := range registry.Summaries {
+		summariesByName[registry.Summaries[i].Name] = prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Name:       registry.Summaries[i].Name,
+			Objectives: registry.Summaries[i].Objectives,
+		}, registry.Summaries[i].Labels)
+
+		err := promRegistry.Register(summariesByName[registry.Summaries[i].Name])
+		if err != nil {
+			return nil, cerrors.New(err, "failed to register summary metric", map[string]interface{}{
+				"name": registry.Summaries[i].Name,
+			})
+		}
+	}
+
+	err := Register(promRegistry, registry.Handles...)
+	if err != nil {
+		return nil, err
+	}
+
 	return &metrics{
 		counters:   countersByName,
 		histograms: histogramsByName,
+		gauges:     gaugesByName,
+		summaries:  summariesByName,
 
 		logger: logger,
 	}, nil
@@ -97,3 +177,96 @@ func (m *metrics) CounterInc(name string, labels map[string]string) {
 
 	metric.Inc()
 }
+
+func (m *metrics) CounterAdd(name string, labels map[string]string, value float64) {
+	if value < 0 {
+		m.logger.WithTags(map[string]interface{}{
+			"name":  name,
+			"value": value,
+		}).Warn("Counter cannot be decreased. Ignoring..", nil)
+
+		return
+	}
+
+	counter, ok := m.counters[name]
+	if !ok {
+		m.logger.WithTags(map[string]interface{}{
+			"name": name,
+		}).Warn("Counter is not registered. Ignoring..", nil)
+
+		return
+	}
+
+	metric, err := counter.GetMetricWith(labels)
+	if err != nil {
+		m.logger.WithTags(map[string]interface{}{
+			"name": name,
+		}).Warn("Failed to get counter metric with labels", err)
+
+		return
+	}
+
+	metric.Add(value)
+}
+
+func (m *metrics) GaugeSet(name string, labels map[string]string, value float64) {
+	metric, ok := m.gauge(name, labels)
+	if !ok {
+		return
+	}
+
+	metric.Set(value)
+}
+
+func (m *metrics) GaugeAdd(name string, labels map[string]string, value float64) {
+	metric, ok := m.gauge(name, labels)
+	if !ok {
+		return
+	}
+
+	metric.Add(value)
+}
+
+func (m *metrics) gauge(name string, labels map[string]string) (prometheus.Gauge, bool) {
+	gauge, ok := m.gauges[name]
+	if !ok {
+		m.logger.WithTags(map[string]interface{}{
+			"name": name,
+		}).Warn("Gauge is not registered. Ignoring..", nil)
+
+		return nil, false
+	}
+
+	metric, err := gauge.GetMetricWith(labels)
+	if err != nil {
+		m.logger.WithTags(map[string]interface{}{
+			"name": name,
+		}).Warn("Failed to get gauge metric with labels", err)
+
+		return nil, false
+	}
+
+	return metric, true
+}
+
+func (m *metrics) SummaryObserve(name string, labels map[string]string, value float64) {
+	summary, ok := m.summaries[name]
+	if !ok {
+		m.logger.WithTags(map[string]interface{}{
+			"name": name,
+		}).Warn("Summary is not registered. Ignoring..", nil)
+
+		return
+	}
+
+	metric, err := summary.GetMetricWith(labels)
+	if err != nil {
+		m.logger.WithTags(map[string]interface{}{
+			"name": name,
+		}).Warn("Failed to get summary metric with labels", err)
+
+		return
+	}
+
+	metric.Observe(value)
+}
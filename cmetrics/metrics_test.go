@@ -0,0 +1,107 @@
+package cmetrics_test
+
+import (
+	"testing"
+
+	"github.com/gocopper/copper/clogger"
+	"github.com/gocopper/copper/cmetrics"
+	"github.com/gocopper/copper/cmetrics/cmetricstest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+
+	var (
+		logs     = make([]clogger.RecordedLog, 0)
+		registry = cmetrics.NewRegistry(cmetrics.NewRegistryParams{
+			Counters: []cmetrics.Counter{{Name: "test_jobs_total", Labels: []string{"queue"}}},
+			Gauges:   []cmetrics.Gauge{{Name: "test_queue_depth", Labels: []string{"queue"}}},
+			Summaries: []cmetrics.Summary{{
+				Name:       "test_job_duration_seconds",
+				Objectives: map[float64]float64{0.5: 0.05, 0.99: 0.001},
+			}},
+		})
+		promRegistry = prometheus.NewRegistry()
+		queue        = map[string]string{"queue": "emails"}
+	)
+
+	metrics, err := cmetrics.NewMetrics(registry, promRegistry, clogger.NewRecorder(&logs))
+	assert.NoError(t, err)
+
+	metrics.CounterInc("test_jobs_total", queue)
+	metrics.CounterAdd("test_jobs_total", queue, 4)
+	metrics.CounterAdd("test_jobs_total", queue, -1)
+
+	metrics.GaugeSet("test_queue_depth", queue, 10)
+	metrics.GaugeAdd("test_queue_depth", queue, -3)
+
+	for i := 1; i <= 100; i++ {
+		metrics.SummaryObserve("test_job_duration_seconds", nil, float64(i))
+	}
+
+	metrics.GaugeSet("test_unknown", nil, 1)
+	metrics.GaugeSet("test_queue_depth", map[string]string{"unknown": "label"}, 1)
+
+	cmetricstest.AssertValue(t, promRegistry, "test_jobs_total", queue, 5)
+	cmetricstest.AssertValue(t, promRegistry, "test_queue_depth", queue, 7)
+	cmetricstest.AssertValue(t, promRegistry, "test_job_duration_seconds", nil, 5050)
+	cmetricstest.AssertSampleCount(t, promRegistry, "test_job_duration_seconds", nil, 100)
+
+	assert.Len(t, logs, 3)
+	assert.Equal(t, "Counter cannot be decreased. Ignoring..", logs[0].Msg)
+	assert.Equal(t, "Gauge is not registered. Ignoring..", logs[1].Msg)
+	assert.Equal(t, "Failed to get gauge metric with labels", logs[2].Msg)
+}
+
+func TestNewMetrics_IsolatedRegistries(t *testing.T) {
+	t.Parallel()
+
+	p := cmetrics.NewRegistryParams{
+		Counters: []cmetrics.Counter{{Name: "test_total"}},
+	}
+
+	metrics1, registry1 := cmetricstest.New(t, p)
+	metrics2, registry2 := cmetricstest.New(t, p)
+
+	metrics1.CounterInc("test_total", nil)
+	metrics2.CounterAdd("test_total", nil, 2)
+
+	cmetricstest.AssertValue(t, registry1, "test_total", nil, 1)
+	cmetricstest.AssertValue(t, registry2, "test_total", nil, 2)
+
+	_, err := cmetrics.NewMetrics(cmetrics.NewRegistry(p), registry1, clogger.NewNoop())
+	assert.Error(t, err)
+}
+
+func TestRegisterDefaults(t *testing.T) {
+	t.Parallel()
+
+	cmetrics.RegisterDefaults(cmetrics.NewRegistryParams{
+		Counters: []cmetrics.Counter{{Name: "test_default_total"}},
+	})
+
+	metrics, registry := cmetricstest.New(t, cmetrics.NewRegistryParams{})
+
+	metrics.CounterInc("test_default_total", nil)
+
+	cmetricstest.AssertValue(t, registry, "test_default_total", nil, 1)
+}
+
+func TestNewPrometheusRegistry(t *testing.T) {
+	t.Parallel()
+
+	registry, err := cmetrics.NewPrometheusRegistry(cmetrics.Config{DefaultCollectors: true})
+	assert.NoError(t, err)
+
+	_, ok := cmetricstest.Value(t, registry, "go_goroutines", nil)
+	assert.True(t, ok)
+
+	registry, err = cmetrics.NewPrometheusRegistry(cmetrics.Config{})
+	assert.NoError(t, err)
+
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+	assert.Empty(t, families)
+}
@@ -1,9 +1,17 @@
 package cmetrics
 
+import (
+	"slices"
+	"sync"
+)
+
 type (
 	Registry struct {
 		Counters   []Counter
 		Histograms []Histogram
+		Gauges     []Gauge
+		Summaries  []Summary
+		Handles    []Handle
 	}
 
 	Counter struct {
@@ -16,11 +24,29 @@ type (
 		Labels  []string
 		Buckets []float64
 	}
+
+	Gauge struct {
+		Name   string
+		Labels []string
+	}
+
+	// Summary tracks the quantiles of observed values. Objectives maps each quantile to its allowed absolute
+	// error (ex. {0.5: 0.05, 0.99: 0.001}).
+	Summary struct {
+		Name       string
+		Labels     []string
+		Objectives map[float64]float64
+	}
 )
 
 type NewRegistryParams struct {
 	Counters   []Counter
 	Histograms []Histogram
+	Gauges     []Gauge
+	Summaries  []Summary
+
+	// Handles are the typed metrics created with NewCounter, NewGauge, NewHistogram, and NewSummary
+	Handles []Handle
 }
 
 var (
@@ -40,9 +66,34 @@ var (
 	}
 )
 
+var (
+	defaultsMu sync.RWMutex      //nolint:gochecknoglobals
+	defaults   NewRegistryParams //nolint:gochecknoglobals
+)
+
+// RegisterDefaults adds the given metrics to every Registry created with NewRegistry so that packages (ex. csql) can
+// define the metrics they report without each app adding them to its NewRegistryParams. Since they are shared by
+// all of the registries in the process, they should be registered once (ex. in an init func).
+func RegisterDefaults(p NewRegistryParams) {
+	defaultsMu.Lock()
+	defer defaultsMu.Unlock()
+
+	defaults.Counters = append(defaults.Counters, p.Counters...)
+	defaults.Histograms = append(defaults.Histograms, p.Histograms...)
+	defaults.Gauges = append(defaults.Gauges, p.Gauges...)
+	defaults.Summaries = append(defaults.Summaries, p.Summaries...)
+	defaults.Handles = append(defaults.Handles, p.Handles...)
+}
+
 func NewRegistry(p NewRegistryParams) *Registry {
+	defaultsMu.RLock()
+	defer defaultsMu.RUnlock()
+
 	return &Registry{
-		Counters:   append(p.Counters, internalCounters...),
-		Histograms: append(p.Histograms, internalHistograms...),
+		Counters:   slices.Concat(p.Counters, internalCounters, defaults.Counters),
+		Histograms: slices.Concat(p.Histograms, internalHistograms, defaults.Histograms),
+		Gauges:     slices.Concat(p.Gauges, defaults.Gauges),
+		Summaries:  slices.Concat(p.Summaries, defaults.Summaries),
+		Handles:    slices.Concat(p.Handles, defaults.Handles),
 	}
 }
@@ -11,3 +11,15 @@ func (m *noop) CounterInc(name string, labels map[string]string) {
 
 func (m *noop) HistogramObserve(name string, labels map[string]string, value float64) {
 }
+
+func (m *noop) CounterAdd(name string, labels map[string]string, value float64) {
+}
+
+func (m *noop) GaugeSet(name string, labels map[string]string, value float64) {
+}
+
+func (m *noop) GaugeAdd(name string, labels map[string]string, value float64) {
+}
+
+func (m *noop) SummaryObserve(name string, labels map[string]string, value float64) {
+}
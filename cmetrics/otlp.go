@@ -0,0 +1,405 @@
+package cmetrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gocopper/copper/cerrors"
+	"github.com/gocopper/copper/clifecycle"
+	"github.com/gocopper/copper/clogger"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// otlpCumulative is the OTLP aggregation temporality of the exported sums and histograms. Prometheus metrics are
+// cumulative since the app started.
+const otlpCumulative = 2
+
+type (
+	// OTLPExporter pushes the metrics registered with the app's prometheus registry to an OpenTelemetry collector
+	// using OTLP/HTTP with JSON encoding. Counters are exported as monotonic sums, gauges as gauges, and histograms
+	// and summaries as their OTLP equivalents.
+	OTLPExporter struct {
+		config   Config
+		gatherer prometheus.Gatherer
+		lc       *clifecycle.Lifecycle
+		logger   clogger.Logger
+		client   *http.Client
+		timeout  time.Duration
+		start    time.Time
+	}
+
+	// NewOTLPExporterParams holds the params needed to create an OTLPExporter
+	NewOTLPExporterParams struct {
+		Config     Config
+		Prometheus *prometheus.Registry
+		Lifecycle  *clifecycle.Lifecycle
+		Logger     clogger.Logger
+	}
+)
+
+// NewOTLPExporter creates a new OTLPExporter
+func NewOTLPExporter(p NewOTLPExporterParams) *OTLPExporter {
+	const DefaultTimeout = 10 * time.Second
+
+	timeout := time.Duration(p.Config.OTLPTimeoutSeconds) * time.Second
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	return &OTLPExporter{
+		config:   p.Config,
+		gatherer: p.Prometheus,
+		lc:       p.Lifecycle,
+		logger:   p.Logger,
+		client:   &http.Client{Timeout: timeout},
+		timeout:  timeout,
+		start:    time.Now(),
+	}
+}
+
+// Run starts pushing the metrics every OTLPIntervalSeconds until the app's context is done. The metrics are pushed
+// one last time by the app's stop funcs. It does nothing unless the otlp exporter is set in Config.
+func (e *OTLPExporter) Run() error {
+	const DefaultInterval = 60 * time.Second
+
+	if e.config.Exporter != ExporterOTLP {
+		return nil
+	}
+
+	interval := time.Duration(e.config.OTLPIntervalSeconds) * time.Second
+	if interval == 0 {
+		interval = DefaultInterval
+	}
+
+	e.lc.OnStop(func(ctx context.Context) error {
+		e.logger.Info("Flushing metrics to otlp endpoint..")
+
+		return e.Export(ctx)
+	})
+
+	e.lc.Go(func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				err := e.Export(ctx)
+				if err != nil {
+					e.logger.Error("Failed to export metrics to otlp endpoint", err)
+				}
+			}
+		}
+	})
+
+	e.logger.WithTags(map[string]any{
+		"endpoint": e.endpoint(),
+		"interval": interval.String(),
+	}).Info("Started exporting metrics to otlp endpoint..")
+
+	return nil
+}
+
+// Export gathers the metrics and pushes them to the otlp endpoint in a single request. The request is canceled if it
+// takes longer than OTLPTimeoutSeconds.
+func (e *OTLPExporter) Export(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	families, err := e.gatherer.Gather()
+	if err != nil {
+		return cerrors.New(err, "failed to gather metrics", nil)
+	}
+
+	body, err := json.Marshal(e.request(families, time.Now()))
+	if err != nil {
+		return cerrors.New(err, "failed to encode otlp metrics request", nil)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint(), bytes.NewReader(body))
+	if err != nil {
+		return cerrors.New(err, "failed to create otlp metrics request", nil)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	for k, v := range e.config.OTLPHeaders {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return cerrors.New(err, "failed to send otlp metrics request", map[string]any{
+			"endpoint": e.endpoint(),
+		})
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return cerrors.New(nil, "otlp endpoint responded with a non-2xx status code", map[string]any{
+			"endpoint":    e.endpoint(),
+			"status_code": resp.StatusCode,
+		})
+	}
+
+	return nil
+}
+
+func (e *OTLPExporter) endpoint() string {
+	const DefaultEndpoint = "http://localhost:4318/v1/metrics"
+
+	if e.config.OTLPEndpoint == "" {
+		return DefaultEndpoint
+	}
+
+	return e.config.OTLPEndpoint
+}
+
+type (
+	otlpMetricsRequest struct {
+		ResourceMetrics []otlpResourceMetrics `json:"resourceMetrics"`
+	}
+
+	otlpResourceMetrics struct {
+		Resource     otlpResource       `json:"resource"`
+		ScopeMetrics []otlpScopeMetrics `json:"scopeMetrics"`
+	}
+
+	otlpResource struct {
+		Attributes []otlpAttribute `json:"attributes"`
+	}
+
+	otlpScopeMetrics struct {
+		Scope   otlpScope    `json:"scope"`
+		Metrics []otlpMetric `json:"metrics"`
+	}
+
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+
+	otlpMetric struct {
+		Name        string         `json:"name"`
+		Description string         `json:"description,omitempty"`
+		Sum         *otlpSum       `json:"sum,omitempty"`
+		Gauge       *otlpGauge     `json:"gauge,omitempty"`
+		Histogram   *otlpHistogram `json:"histogram,omitempty"`
+		Summary     *otlpSummary   `json:"summary,omitempty"`
+	}
+
+	otlpSum struct {
+		DataPoints             []otlpNumberDataPoint `json:"dataPoints"`
+		AggregationTemporality int                   `json:"aggregationTemporality"`
+		IsMonotonic            bool                  `json:"isMonotonic"`
+	}
+
+	otlpGauge struct {
+		DataPoints []otlpNumberDataPoint `json:"dataPoints"`
+	}
+
+	otlpHistogram struct {
+		DataPoints             []otlpHistogramDataPoint `json:"dataPoints"`
+		AggregationTemporality int                      `json:"aggregationTemporality"`
+	}
+
+	otlpSummary struct {
+		DataPoints []otlpSummaryDataPoint `json:"dataPoints"`
+	}
+
+	otlpNumberDataPoint struct {
+		Attributes        []otlpAttribute `json:"attributes,omitempty"`
+		StartTimeUnixNano string          `json:"startTimeUnixNano"`
+		TimeUnixNano      string          `json:"timeUnixNano"`
+		AsDouble          float64         `json:"asDouble"`
+	}
+
+	otlpHistogramDataPoint struct {
+		Attributes        []otlpAttribute `json:"attributes,omitempty"`
+		StartTimeUnixNano string          `json:"startTimeUnixNano"`
+		TimeUnixNano      string          `json:"timeUnixNano"`
+		Count             string          `json:"count"`
+		Sum               float64         `json:"sum"`
+		BucketCounts      []string        `json:"bucketCounts"`
+		ExplicitBounds    []float64       `json:"explicitBounds"`
+	}
+
+	otlpSummaryDataPoint struct {
+		Attributes        []otlpAttribute     `json:"attributes,omitempty"`
+		StartTimeUnixNano string              `json:"startTimeUnixNano"`
+		TimeUnixNano      string              `json:"timeUnixNano"`
+		Count             string              `json:"count"`
+		Sum               float64             `json:"sum"`
+		QuantileValues    []otlpQuantileValue `json:"quantileValues"`
+	}
+
+	otlpQuantileValue struct {
+		Quantile float64 `json:"quantile"`
+		Value    float64 `json:"value"`
+	}
+
+	otlpAttribute struct {
+		Key   string       `json:"key"`
+		Value otlpAnyValue `json:"value"`
+	}
+
+	otlpAnyValue struct {
+		StringValue string `json:"stringValue"`
+	}
+)
+
+func (e *OTLPExporter) request(families []*dto.MetricFamily, now time.Time) otlpMetricsRequest {
+	resource := make([]otlpAttribute, 0, 3)
+	for _, attr := range []otlpAttribute{
+		otlpAttr("service.name", e.config.ServiceName),
+		otlpAttr("service.version", e.config.ServiceVersion),
+		otlpAttr("deployment.environment", e.config.Environment),
+	} {
+		if attr.Value.StringValue != "" {
+			resource = append(resource, attr)
+		}
+	}
+
+	var (
+		start   = strconv.FormatInt(e.start.UnixNano(), 10)
+		ts      = strconv.FormatInt(now.UnixNano(), 10)
+		metrics = make([]otlpMetric, 0, len(families))
+	)
+
+	for _, f := range families {
+		metrics = append(metrics, otlpMetricOf(f, start, ts))
+	}
+
+	return otlpMetricsRequest{
+		ResourceMetrics: []otlpResourceMetrics{{
+			Resource: otlpResource{Attributes: resource},
+			ScopeMetrics: []otlpScopeMetrics{{
+				Scope:   otlpScope{Name: "github.com/gocopper/copper/cmetrics"},
+				Metrics: metrics,
+			}},
+		}},
+	}
+}
+
+func otlpMetricOf(f *dto.MetricFamily, start, ts string) otlpMetric {
+	m := otlpMetric{
+		Name:        f.GetName(),
+		Description: f.GetHelp(),
+	}
+
+	switch f.GetType() {
+	case dto.MetricType_COUNTER:
+		m.Sum = &otlpSum{AggregationTemporality: otlpCumulative, IsMonotonic: true}
+
+		for _, pm := range f.GetMetric() {
+			m.Sum.DataPoints = append(m.Sum.DataPoints, otlpNumberDataPoint{
+				Attributes:        otlpLabels(pm.GetLabel()),
+				StartTimeUnixNano: start,
+				TimeUnixNano:      ts,
+				AsDouble:          pm.GetCounter().GetValue(),
+			})
+		}
+	case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+		m.Histogram = &otlpHistogram{AggregationTemporality: otlpCumulative}
+
+		for _, pm := range f.GetMetric() {
+			m.Histogram.DataPoints = append(m.Histogram.DataPoints, otlpHistogramPoint(pm, start, ts))
+		}
+	case dto.MetricType_SUMMARY:
+		m.Summary = &otlpSummary{}
+
+		for _, pm := range f.GetMetric() {
+			dp := otlpSummaryDataPoint{
+				Attributes:        otlpLabels(pm.GetLabel()),
+				StartTimeUnixNano: start,
+				TimeUnixNano:      ts,
+				Count:             strconv.FormatUint(pm.GetSummary().GetSampleCount(), 10),
+				Sum:               pm.GetSummary().GetSampleSum(),
+				QuantileValues:    make([]otlpQuantileValue, 0, len(pm.GetSummary().GetQuantile())),
+			}
+
+			for _, q := range pm.GetSummary().GetQuantile() {
+				dp.QuantileValues = append(dp.QuantileValues, otlpQuantileValue{
+					Quantile: q.GetQuantile(),
+					Value:    q.GetValue(),
+				})
+			}
+
+			m.Summary.DataPoints = append(m.Summary.DataPoints, dp)
+		}
+	default:
+		m.Gauge = &otlpGauge{}
+
+		for _, pm := range f.GetMetric() {
+			value := pm.GetGauge().GetValue()
+			if pm.GetUntyped() != nil {
+				value = pm.GetUntyped().GetValue()
+			}
+
+			m.Gauge.DataPoints = append(m.Gauge.DataPoints, otlpNumberDataPoint{
+				Attributes:        otlpLabels(pm.GetLabel()),
+				StartTimeUnixNano: start,
+				TimeUnixNano:      ts,
+				AsDouble:          value,
+			})
+		}
+	}
+
+	return m
+}
+
+// otlpHistogramPoint converts the cumulative prometheus buckets to the per-bucket counts used by OTLP. The last
+// bucket holds the values above the highest bound.
+func otlpHistogramPoint(pm *dto.Metric, start, ts string) otlpHistogramDataPoint {
+	var (
+		h      = pm.GetHistogram()
+		bounds = make([]float64, 0, len(h.GetBucket()))
+		counts = make([]string, 0, len(h.GetBucket())+1)
+		prev   uint64
+	)
+
+	for _, b := range h.GetBucket() {
+		if math.IsInf(b.GetUpperBound(), 1) {
+			continue
+		}
+
+		bounds = append(bounds, b.GetUpperBound())
+		counts = append(counts, strconv.FormatUint(b.GetCumulativeCount()-prev, 10))
+		prev = b.GetCumulativeCount()
+	}
+
+	counts = append(counts, strconv.FormatUint(h.GetSampleCount()-prev, 10))
+
+	return otlpHistogramDataPoint{
+		Attributes:        otlpLabels(pm.GetLabel()),
+		StartTimeUnixNano: start,
+		TimeUnixNano:      ts,
+		Count:             strconv.FormatUint(h.GetSampleCount(), 10),
+		Sum:               h.GetSampleSum(),
+		BucketCounts:      counts,
+		ExplicitBounds:    bounds,
+	}
+}
+
+func otlpLabels(labels []*dto.LabelPair) []otlpAttribute {
+	attrs := make([]otlpAttribute, 0, len(labels))
+	for _, l := range labels {
+		attrs = append(attrs, otlpAttr(l.GetName(), l.GetValue()))
+	}
+
+	return attrs
+}
+
+func otlpAttr(key, value string) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpAnyValue{StringValue: value}}
+}
@@ -0,0 +1,246 @@
+package cmetrics_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gocopper/copper/clifecycle/clifecycletest"
+	"github.com/gocopper/copper/clogger"
+	"github.com/gocopper/copper/cmetrics"
+	"github.com/gocopper/copper/cmetrics/cmetricstest"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeCollector records the OTLP metrics requests that it receives
+type fakeCollector struct {
+	mu       sync.Mutex
+	requests []map[string]any
+	headers  []http.Header
+}
+
+func (c *fakeCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body map[string]any
+
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	c.mu.Lock()
+	c.requests = append(c.requests, body)
+	c.headers = append(c.headers, r.Header.Clone())
+	c.mu.Unlock()
+}
+
+func (c *fakeCollector) metrics(t *testing.T, i int) map[string]map[string]any {
+	t.Helper()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var req struct {
+		ResourceMetrics []struct {
+			Resource     map[string]any `json:"resource"`
+			ScopeMetrics []struct {
+				Metrics []map[string]any `json:"metrics"`
+			} `json:"scopeMetrics"`
+		} `json:"resourceMetrics"`
+	}
+
+	b, err := json.Marshal(c.requests[i])
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(b, &req))
+
+	metrics := make(map[string]map[string]any)
+	for _, m := range req.ResourceMetrics[0].ScopeMetrics[0].Metrics {
+		metrics[m["name"].(string)] = m
+	}
+
+	metrics["resource"] = req.ResourceMetrics[0].Resource
+
+	return metrics
+}
+
+func TestOTLPExporter_Export(t *testing.T) {
+	t.Parallel()
+
+	var (
+		collector = &fakeCollector{}
+		server    = httptest.NewServer(collector)
+	)
+	defer server.Close()
+
+	metrics, registry := cmetricstest.New(t, cmetrics.NewRegistryParams{
+		Counters:   []cmetrics.Counter{{Name: "test_jobs_total", Labels: []string{"queue"}}},
+		Gauges:     []cmetrics.Gauge{{Name: "test_queue_depth"}},
+		Histograms: []cmetrics.Histogram{{Name: "test_job_duration_seconds", Buckets: []float64{1, 5}}},
+	})
+
+	metrics.CounterAdd("test_jobs_total", map[string]string{"queue": "emails"}, 3)
+	metrics.GaugeSet("test_queue_depth", nil, 7)
+	metrics.HistogramObserve("test_job_duration_seconds", nil, 0.5)
+	metrics.HistogramObserve("test_job_duration_seconds", nil, 2)
+	metrics.HistogramObserve("test_job_duration_seconds", nil, 10)
+
+	exporter := cmetrics.NewOTLPExporter(cmetrics.NewOTLPExporterParams{
+		Config: cmetrics.Config{
+			Exporter:       cmetrics.ExporterOTLP,
+			OTLPEndpoint:   server.URL,
+			OTLPHeaders:    map[string]string{"Authorization": "Bearer test-token"},
+			ServiceName:    "test-service",
+			ServiceVersion: "1.0.0",
+			Environment:    "test",
+		},
+		Prometheus: registry,
+		Lifecycle:  clifecycletest.New(),
+		Logger:     clogger.NewNoop(),
+	})
+
+	assert.NoError(t, exporter.Export(context.Background()))
+	assert.Len(t, collector.requests, 1)
+	assert.Equal(t, "Bearer test-token", collector.headers[0].Get("Authorization"))
+
+	got := collector.metrics(t, 0)
+
+	assert.Equal(t, []any{
+		map[string]any{"key": "service.name", "value": map[string]any{"stringValue": "test-service"}},
+		map[string]any{"key": "service.version", "value": map[string]any{"stringValue": "1.0.0"}},
+		map[string]any{"key": "deployment.environment", "value": map[string]any{"stringValue": "test"}},
+	}, got["resource"]["attributes"])
+
+	sum := got["test_jobs_total"]["sum"].(map[string]any)
+	assert.Equal(t, true, sum["isMonotonic"])
+	assert.Equal(t, float64(2), sum["aggregationTemporality"])
+
+	point := sum["dataPoints"].([]any)[0].(map[string]any)
+	assert.Equal(t, float64(3), point["asDouble"])
+	assert.Equal(t, []any{
+		map[string]any{"key": "queue", "value": map[string]any{"stringValue": "emails"}},
+	}, point["attributes"])
+
+	gauge := got["test_queue_depth"]["gauge"].(map[string]any)
+	assert.Equal(t, float64(7), gauge["dataPoints"].([]any)[0].(map[string]any)["asDouble"])
+
+	histogram := got["test_job_duration_seconds"]["histogram"].(map[string]any)
+	point = histogram["dataPoints"].([]any)[0].(map[string]any)
+	assert.Equal(t, "3", point["count"])
+	assert.Equal(t, 12.5, point["sum"])
+	assert.Equal(t, []any{float64(1), float64(5)}, point["explicitBounds"])
+	assert.Equal(t, []any{"1", "1", "1"}, point["bucketCounts"])
+}
+
+func TestOTLPExporter_Export_ErrStatusCode(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, registry := cmetricstest.New(t, cmetrics.NewRegistryParams{})
+
+	exporter := cmetrics.NewOTLPExporter(cmetrics.NewOTLPExporterParams{
+		Config:     cmetrics.Config{Exporter: cmetrics.ExporterOTLP, OTLPEndpoint: server.URL},
+		Prometheus: registry,
+		Lifecycle:  clifecycletest.New(),
+		Logger:     clogger.NewNoop(),
+	})
+
+	assert.Error(t, exporter.Export(context.Background()))
+}
+
+func TestOTLPExporter_Export_Timeout(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	_, registry := cmetricstest.New(t, cmetrics.NewRegistryParams{})
+
+	exporter := cmetrics.NewOTLPExporter(cmetrics.NewOTLPExporterParams{
+		Config: cmetrics.Config{
+			Exporter:           cmetrics.ExporterOTLP,
+			OTLPEndpoint:       server.URL,
+			OTLPTimeoutSeconds: 1,
+		},
+		Prometheus: registry,
+		Lifecycle:  clifecycletest.New(),
+		Logger:     clogger.NewNoop(),
+	})
+
+	start := time.Now()
+
+	assert.Error(t, exporter.Export(context.Background()))
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestOTLPExporter_Run_FlushOnStop(t *testing.T) {
+	t.Parallel()
+
+	var (
+		collector = &fakeCollector{}
+		server    = httptest.NewServer(collector)
+		lc        = clifecycletest.New()
+	)
+	defer server.Close()
+
+	metrics, registry := cmetricstest.New(t, cmetrics.NewRegistryParams{
+		Counters: []cmetrics.Counter{{Name: "test_total"}},
+	})
+
+	exporter := cmetrics.NewOTLPExporter(cmetrics.NewOTLPExporterParams{
+		Config: cmetrics.Config{
+			Exporter:            cmetrics.ExporterOTLP,
+			OTLPEndpoint:        server.URL,
+			OTLPIntervalSeconds: 3600,
+		},
+		Prometheus: registry,
+		Lifecycle:  lc,
+		Logger:     clogger.NewNoop(),
+	})
+
+	assert.NoError(t, exporter.Run())
+
+	metrics.CounterInc("test_total", nil)
+
+	assert.NoError(t, lc.Stop(clogger.NewNoop()))
+	assert.Len(t, collector.requests, 1)
+
+	sum := collector.metrics(t, 0)["test_total"]["sum"].(map[string]any)
+	assert.Equal(t, float64(1), sum["dataPoints"].([]any)[0].(map[string]any)["asDouble"])
+}
+
+func TestOTLPExporter_Run_PrometheusExporter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		collector = &fakeCollector{}
+		server    = httptest.NewServer(collector)
+		lc        = clifecycletest.New()
+	)
+	defer server.Close()
+
+	_, registry := cmetricstest.New(t, cmetrics.NewRegistryParams{})
+
+	exporter := cmetrics.NewOTLPExporter(cmetrics.NewOTLPExporterParams{
+		Config:     cmetrics.Config{Exporter: cmetrics.ExporterPrometheus, OTLPEndpoint: server.URL},
+		Prometheus: registry,
+		Lifecycle:  lc,
+		Logger:     clogger.NewNoop(),
+	})
+
+	assert.NoError(t, exporter.Run())
+	assert.NoError(t, lc.Stop(clogger.NewNoop()))
+	assert.Empty(t, collector.requests)
+}
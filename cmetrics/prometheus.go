@@ -0,0 +1,29 @@
+package cmetrics
+
+import (
+	"github.com/gocopper/copper/cerrors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// NewPrometheusRegistry creates the app's own prometheus registry so that metrics are not registered globally. If
+// enabled in Config, the Go runtime and process collectors are registered with it as well.
+func NewPrometheusRegistry(config Config) (*prometheus.Registry, error) {
+	registry := prometheus.NewRegistry()
+
+	if !config.DefaultCollectors {
+		return registry, nil
+	}
+
+	err := registry.Register(collectors.NewGoCollector())
+	if err != nil {
+		return nil, cerrors.New(err, "failed to register go collector", nil)
+	}
+
+	err = registry.Register(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	if err != nil {
+		return nil, cerrors.New(err, "failed to register process collector", nil)
+	}
+
+	return registry, nil
+}
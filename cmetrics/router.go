@@ -0,0 +1,76 @@
+package cmetrics
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gocopper/copper/chttp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type (
+	// Router serves the registered metrics in the Prometheus exposition format. If the metrics are served on a
+	// separate port, the router has no routes and Server serves them instead.
+	Router struct {
+		config  Config
+		handler http.Handler
+	}
+
+	// NewRouterParams holds the params needed to instantiate a new Router
+	NewRouterParams struct {
+		Config     Config
+		Prometheus *prometheus.Registry
+	}
+)
+
+// NewRouter instantiates a new Router
+func NewRouter(p NewRouterParams) *Router {
+	return &Router{
+		config:  p.Config,
+		handler: promhttp.HandlerFor(p.Prometheus, promhttp.HandlerOpts{}),
+	}
+}
+
+// Routes defines the HTTP routes for this router
+func (ro *Router) Routes() []chttp.Route {
+	if ro.config.Port != 0 {
+		return []chttp.Route{}
+	}
+
+	return []chttp.Route{
+		{
+			Path:    ro.config.Path,
+			Methods: []string{http.MethodGet},
+			Handler: ro.HandleMetrics,
+		},
+	}
+}
+
+// HandleMetrics writes the registered metrics after checking the basic auth credentials, if they are configured
+func (ro *Router) HandleMetrics(w http.ResponseWriter, r *http.Request) {
+	if !ro.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="metrics"`)
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	ro.handler.ServeHTTP(w, r)
+}
+
+func (ro *Router) authorized(r *http.Request) bool {
+	if ro.config.BasicAuthUsername == "" && ro.config.BasicAuthPassword == "" {
+		return true
+	}
+
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return false
+	}
+
+	usernameOK := subtle.ConstantTimeCompare([]byte(username), []byte(ro.config.BasicAuthUsername)) == 1
+	passwordOK := subtle.ConstantTimeCompare([]byte(password), []byte(ro.config.BasicAuthPassword)) == 1
+
+	return usernameOK && passwordOK
+}
@@ -0,0 +1,80 @@
+package cmetrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gocopper/copper/cmetrics"
+	"github.com/gocopper/copper/cmetrics/cmetricstest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_HandleMetrics(t *testing.T) {
+	t.Parallel()
+
+	metrics, registry := cmetricstest.New(t, cmetrics.NewRegistryParams{
+		Counters: []cmetrics.Counter{{Name: "test_total"}},
+	})
+
+	metrics.CounterInc("test_total", nil)
+
+	router := cmetrics.NewRouter(cmetrics.NewRouterParams{
+		Config:     cmetrics.Config{Path: "/metrics"},
+		Prometheus: registry,
+	})
+
+	routes := router.Routes()
+	assert.Len(t, routes, 1)
+	assert.Equal(t, "/metrics", routes[0].Path)
+
+	resp := httptest.NewRecorder()
+	routes[0].Handler(resp, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), "test_total 1")
+}
+
+func TestRouter_HandleMetrics_BasicAuth(t *testing.T) {
+	t.Parallel()
+
+	router := cmetrics.NewRouter(cmetrics.NewRouterParams{
+		Config: cmetrics.Config{
+			Path:              "/metrics",
+			BasicAuthUsername: "test-user",
+			BasicAuthPassword: "test-pass",
+		},
+		Prometheus: prometheus.NewRegistry(),
+	})
+
+	resp := httptest.NewRecorder()
+	router.HandleMetrics(resp, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	assert.NotEmpty(t, resp.Header().Get("WWW-Authenticate"))
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.SetBasicAuth("test-user", "wrong-pass")
+
+	resp = httptest.NewRecorder()
+	router.HandleMetrics(resp, req)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.SetBasicAuth("test-user", "test-pass")
+
+	resp = httptest.NewRecorder()
+	router.HandleMetrics(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestRouter_Routes_SeparatePort(t *testing.T) {
+	t.Parallel()
+
+	router := cmetrics.NewRouter(cmetrics.NewRouterParams{
+		Config:     cmetrics.Config{Path: "/metrics", Port: 9090},
+		Prometheus: prometheus.NewRegistry(),
+	})
+
+	assert.Empty(t, router.Routes())
+}
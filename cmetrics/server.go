@@ -0,0 +1,77 @@
+package cmetrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gocopper/copper/clifecycle"
+	"github.com/gocopper/copper/clogger"
+)
+
+// NewServerParams holds the params needed to create a Server.
+type NewServerParams struct {
+	Router    *Router
+	Lifecycle *clifecycle.Lifecycle
+	Config    Config
+	Logger    clogger.Logger
+}
+
+// NewServer creates a new Server.
+func NewServer(p NewServerParams) *Server {
+	const ReadTimeout = 10 * time.Second
+
+	return &Server{
+		router: p.Router,
+		config: p.Config,
+		logger: p.Logger,
+		lc:     p.Lifecycle,
+		internal: http.Server{
+			ReadTimeout: ReadTimeout,
+		},
+	}
+}
+
+// Server serves the metrics on the internal port set in Config so that they are not exposed with the app's routes.
+type Server struct {
+	router *Router
+	config Config
+	logger clogger.Logger
+	lc     *clifecycle.Lifecycle
+
+	internal http.Server
+}
+
+// Run starts the internal metrics server. It does nothing if the metrics are served by the app's http server.
+func (s *Server) Run() error {
+	if s.config.Port == 0 {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(s.config.Path, s.router.HandleMetrics)
+
+	s.internal.Addr = fmt.Sprintf(":%d", s.config.Port)
+	s.internal.Handler = mux
+
+	s.lc.OnStop(func(ctx context.Context) error {
+		s.logger.Info("Shutting down metrics server..")
+
+		return s.internal.Shutdown(ctx)
+	})
+
+	go func() {
+		s.logger.
+			WithTags(map[string]interface{}{"port": s.config.Port}).
+			Info("Starting metrics server..")
+
+		err := s.internal.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("Metrics server did not close cleanly", err)
+		}
+	}()
+
+	return nil
+}
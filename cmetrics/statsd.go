@@ -0,0 +1,428 @@
+package cmetrics
+
+import (
+	"bytes"
+	"context"
+	"math/rand/v2"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gocopper/copper/cerrors"
+	"github.com/gocopper/copper/clifecycle"
+	"github.com/gocopper/copper/clogger"
+)
+
+// maxStatsDSamples is the max number of values of a histogram or summary that are kept between flushes. Once it is
+// reached, a random sample of the values is kept and sent with the matching sample rate.
+const maxStatsDSamples = 1000
+
+type (
+	// StatsDMetrics is a Metrics implementation that sends metrics to a StatsD agent over UDP or a unix datagram
+	// socket. Labels are sent as DogStatsD tags. Counters are aggregated between flushes and gauges are kept
+	// client-side so that their absolute value is sent on every flush until they expire. The values observed by
+	// histograms and summaries are sent as timings or distributions.
+	StatsDMetrics struct {
+		conn        net.Conn
+		prefix      string
+		tags        string
+		histType    string
+		multiValue  bool
+		maxPacket   int
+		gaugeExpiry int
+		logger      clogger.Logger
+
+		mu       sync.Mutex
+		counters map[statsdKey]float64
+		gauges   map[statsdKey]*statsdGauge
+		samples  map[statsdKey]*statsdSamples
+	}
+
+	// statsdKey identifies a metric by its name and its formatted tags
+	statsdKey struct {
+		name string
+		tags string
+	}
+
+	// statsdGauge holds the value of a gauge along with the number of flushes since it was last updated
+	statsdGauge struct {
+		value float64
+		idle  int
+	}
+
+	// statsdSamples holds the values observed since the last flush. Once there are maxStatsDSamples values, each new
+	// value replaces a random one so that the values are a uniform sample of the count observed values.
+	statsdSamples struct {
+		values []float64
+		count  int
+	}
+)
+
+// NewStatsDMetrics connects to the StatsD agent set in Config and starts sending the metrics every
+// StatsDFlushIntervalMS. The remaining metrics are sent, and the connection is closed, when the app stops.
+func NewStatsDMetrics(config Config, lc *clifecycle.Lifecycle, logger clogger.Logger) (*StatsDMetrics, error) {
+	const (
+		DefaultNetwork       = "udp"
+		DefaultAddr          = "127.0.0.1:8125"
+		DefaultFlushInterval = time.Second
+		DefaultMaxPacketSize = 1432
+		DefaultGaugeExpiry   = 60
+	)
+
+	network, addr := config.StatsDNetwork, config.StatsDAddr
+	if network == "" {
+		network = DefaultNetwork
+	}
+
+	if addr == "" {
+		addr = DefaultAddr
+	}
+
+	conn, err := net.Dial(network, addr)
+	if err != nil {
+		return nil, cerrors.New(err, "failed to connect to statsd", map[string]any{
+			"network": network,
+			"addr":    addr,
+		})
+	}
+
+	m := &StatsDMetrics{
+		conn:        conn,
+		prefix:      sanitizeStatsDName(config.StatsDPrefix),
+		tags:        statsdTags(config.StatsDTags),
+		histType:    "ms",
+		multiValue:  config.StatsDMultiValue,
+		maxPacket:   int(config.StatsDMaxPacketSize),
+		gaugeExpiry: int(config.StatsDGaugeExpiryFlushes),
+		logger:      logger,
+		counters:    make(map[statsdKey]float64),
+		gauges:      make(map[statsdKey]*statsdGauge),
+		samples:     make(map[statsdKey]*statsdSamples),
+	}
+
+	if config.StatsDHistogramType == StatsDHistogramDistribution {
+		m.histType = "d"
+	}
+
+	if m.maxPacket <= 0 {
+		m.maxPacket = DefaultMaxPacketSize
+	}
+
+	if m.gaugeExpiry <= 0 {
+		m.gaugeExpiry = DefaultGaugeExpiry
+	}
+
+	interval := time.Duration(config.StatsDFlushIntervalMS) * time.Millisecond
+	if interval == 0 {
+		interval = DefaultFlushInterval
+	}
+
+	lc.OnStop(func(ctx context.Context) error {
+		err := m.Flush()
+
+		closeErr := m.conn.Close()
+		if err == nil && closeErr != nil {
+			err = cerrors.New(closeErr, "failed to close statsd connection", nil)
+		}
+
+		return err
+	})
+
+	lc.Go(func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				err := m.Flush()
+				if err != nil {
+					m.logger.Error("Failed to send metrics to statsd", err)
+				}
+			}
+		}
+	})
+
+	return m, nil
+}
+
+// CounterInc increments the counter by 1.
+func (m *StatsDMetrics) CounterInc(name string, labels map[string]string) {
+	m.CounterAdd(name, labels, 1)
+}
+
+// CounterAdd increments the counter by the given value, which must not be negative.
+func (m *StatsDMetrics) CounterAdd(name string, labels map[string]string, value float64) {
+	if value < 0 {
+		m.logger.WithTags(map[string]interface{}{
+			"name":  name,
+			"value": value,
+		}).Warn("Counter cannot be decreased. Ignoring..", nil)
+
+		return
+	}
+
+	key := m.key(name, labels)
+
+	m.mu.Lock()
+	m.counters[key] += value
+	m.mu.Unlock()
+}
+
+// HistogramObserve sends the value as a timing or distribution.
+func (m *StatsDMetrics) HistogramObserve(name string, labels map[string]string, value float64) {
+	key := m.key(name, labels)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	samples, ok := m.samples[key]
+	if !ok {
+		samples = &statsdSamples{}
+		m.samples[key] = samples
+	}
+
+	samples.count++
+
+	if len(samples.values) < maxStatsDSamples {
+		samples.values = append(samples.values, value)
+		return
+	}
+
+	if i := rand.IntN(samples.count); i < maxStatsDSamples { //nolint:gosec
+		samples.values[i] = value
+	}
+}
+
+// SummaryObserve sends the value as a timing or distribution. The quantiles are computed by the StatsD agent.
+func (m *StatsDMetrics) SummaryObserve(name string, labels map[string]string, value float64) {
+	m.HistogramObserve(name, labels, value)
+}
+
+// GaugeSet sets the gauge to the given value.
+func (m *StatsDMetrics) GaugeSet(name string, labels map[string]string, value float64) {
+	key := m.key(name, labels)
+
+	m.mu.Lock()
+	m.gauges[key] = &statsdGauge{value: value}
+	m.mu.Unlock()
+}
+
+// GaugeAdd adds the given value, which may be negative, to the gauge. Gauges that have not been set, or that have
+// expired, start at 0.
+func (m *StatsDMetrics) GaugeAdd(name string, labels map[string]string, value float64) {
+	key := m.key(name, labels)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	g, ok := m.gauges[key]
+	if !ok {
+		m.gauges[key] = &statsdGauge{value: value}
+		return
+	}
+
+	g.value += value
+	g.idle = 0
+}
+
+// GaugeDelete stops sending the gauge.
+func (m *StatsDMetrics) GaugeDelete(name string, labels map[string]string) {
+	key := m.key(name, labels)
+
+	m.mu.Lock()
+	delete(m.gauges, key)
+	m.mu.Unlock()
+}
+
+// Flush sends the counters and samples aggregated since the last flush along with the current value of every gauge,
+// batched into as few packets as possible. Gauges that have not been updated for StatsDGaugeExpiryFlushes flushes
+// are no longer sent.
+func (m *StatsDMetrics) Flush() error {
+	m.mu.Lock()
+	counters, samples := m.counters, m.samples
+	m.counters = make(map[statsdKey]float64)
+	m.samples = make(map[statsdKey]*statsdSamples)
+
+	lines := make([]string, 0, len(counters)+len(m.gauges)+len(samples))
+
+	// Gauges are always sent as their absolute value since DogStatsD does not support relative gauges
+	for key, g := range m.gauges {
+		lines = append(lines, m.line(key, []string{formatStatsDValue(g.value)}, "g", 1))
+
+		g.idle++
+		if g.idle >= m.gaugeExpiry {
+			delete(m.gauges, key)
+		}
+	}
+	m.mu.Unlock()
+
+	for key, value := range counters {
+		lines = append(lines, m.line(key, []string{formatStatsDValue(value)}, "c", 1))
+	}
+
+	for key, s := range samples {
+		values := make([]string, len(s.values))
+		for i := range s.values {
+			values[i] = formatStatsDValue(s.values[i])
+		}
+
+		sampleRate := float64(len(s.values)) / float64(s.count)
+
+		if !m.multiValue {
+			for _, v := range values {
+				lines = append(lines, m.line(key, []string{v}, m.histType, sampleRate))
+			}
+
+			continue
+		}
+
+		lines = append(lines, m.multiValueLines(key, values, sampleRate)...)
+	}
+
+	return m.send(lines)
+}
+
+// send writes the lines in packets of up to maxPacket bytes. Lines that are larger than maxPacket are sent in a
+// packet of their own.
+func (m *StatsDMetrics) send(lines []string) error {
+	var packet bytes.Buffer
+
+	write := func() error {
+		if packet.Len() == 0 {
+			return nil
+		}
+
+		_, err := m.conn.Write(packet.Bytes())
+		packet.Reset()
+
+		if err != nil {
+			return cerrors.New(err, "failed to write statsd packet", nil)
+		}
+
+		return nil
+	}
+
+	for _, line := range lines {
+		if packet.Len() > 0 && packet.Len()+1+len(line) > m.maxPacket {
+			err := write()
+			if err != nil {
+				return err
+			}
+		}
+
+		if packet.Len() > 0 {
+			packet.WriteByte('\n')
+		}
+
+		packet.WriteString(line)
+	}
+
+	return write()
+}
+
+func (m *StatsDMetrics) key(name string, labels map[string]string) statsdKey {
+	return statsdKey{name: m.prefix + sanitizeStatsDName(name), tags: statsdTags(labels)}
+}
+
+// multiValueLines sends the values of a histogram or summary using as few lines as possible, each of which fits in
+// a packet. Multi-value lines require DogStatsD 1.1 or later.
+func (m *StatsDMetrics) multiValueLines(key statsdKey, values []string, sampleRate float64) []string {
+	var (
+		lines    = make([]string, 0, 1)
+		overhead = len(m.line(key, nil, m.histType, sampleRate))
+		start    = 0
+		size     = overhead
+	)
+
+	for i, v := range values {
+		if i > start && size+1+len(v) > m.maxPacket {
+			lines = append(lines, m.line(key, values[start:i], m.histType, sampleRate))
+			start, size = i, overhead
+		}
+
+		size += 1 + len(v)
+	}
+
+	return append(lines, m.line(key, values[start:], m.histType, sampleRate))
+}
+
+// line formats the metric using the DogStatsD format - name:value1:value2|type|@sample_rate|#tags.
+func (m *StatsDMetrics) line(key statsdKey, values []string, metricType string, sampleRate float64) string {
+	var b strings.Builder
+
+	b.WriteString(key.name)
+
+	for _, v := range values {
+		b.WriteByte(':')
+		b.WriteString(v)
+	}
+
+	b.WriteByte('|')
+	b.WriteString(metricType)
+
+	if sampleRate < 1 {
+		b.WriteString("|@")
+		b.WriteString(strconv.FormatFloat(sampleRate, 'f', 4, 64))
+	}
+
+	tags := joinStatsDTags(m.tags, key.tags)
+	if tags != "" {
+		b.WriteString("|#")
+		b.WriteString(tags)
+	}
+
+	return b.String()
+}
+
+// statsdTags formats the tags as sorted "key:value" pairs separated by commas. Characters that are used by the
+// DogStatsD format are replaced with underscores.
+func statsdTags(tags map[string]string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, sanitizeStatsDTag(k)+":"+sanitizeStatsDTag(v))
+	}
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+func joinStatsDTags(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
+	default:
+		return a + "," + b
+	}
+}
+
+//nolint:gochecknoglobals
+var (
+	statsdTagReplacer  = strings.NewReplacer("|", "_", ",", "_", "#", "_", "\n", "_")
+	statsdNameReplacer = strings.NewReplacer(":", "_", "|", "_", "@", "_", "#", "_", ",", "_", " ", "_", "\n", "_")
+)
+
+func sanitizeStatsDTag(s string) string {
+	return statsdTagReplacer.Replace(s)
+}
+
+// sanitizeStatsDName replaces the characters that are used by the DogStatsD format in metric names with underscores.
+func sanitizeStatsDName(s string) string {
+	return statsdNameReplacer.Replace(s)
+}
+
+func formatStatsDValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
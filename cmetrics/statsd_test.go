@@ -0,0 +1,319 @@
+package cmetrics_test
+
+import (
+	"net"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gocopper/copper/clifecycle/clifecycletest"
+	"github.com/gocopper/copper/clogger"
+	"github.com/gocopper/copper/cmetrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+// readStatsDPackets reads the packets sent to the listener until it has been idle for a short while
+func readStatsDPackets(t *testing.T, conn net.PacketConn) []string {
+	t.Helper()
+
+	var (
+		packets = make([]string, 0)
+		buf     = make([]byte, 65536)
+	)
+
+	for {
+		assert.NoError(t, conn.SetReadDeadline(time.Now().Add(200*time.Millisecond)))
+
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return packets
+		}
+
+		packets = append(packets, string(buf[:n]))
+	}
+}
+
+func statsDLines(packets []string) []string {
+	lines := make([]string, 0)
+	for _, p := range packets {
+		lines = append(lines, strings.Split(p, "\n")...)
+	}
+
+	sort.Strings(lines)
+
+	return lines
+}
+
+// statsDValues returns the values of multi-value lines (ex. name:1:2|ms)
+func statsDValues(lines []string) []string {
+	values := make([]string, 0)
+	for _, line := range lines {
+		metric, _, _ := strings.Cut(line, "|")
+		values = append(values, strings.Split(metric, ":")[1:]...)
+	}
+
+	return values
+}
+
+func TestStatsDMetrics(t *testing.T) {
+	t.Parallel()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	metrics, err := cmetrics.NewStatsDMetrics(cmetrics.Config{
+		StatsDAddr:            conn.LocalAddr().String(),
+		StatsDPrefix:          "test.",
+		StatsDTags:            map[string]string{"env": "test"},
+		StatsDFlushIntervalMS: 3600000,
+		StatsDHistogramType:   cmetrics.StatsDHistogramDistribution,
+		StatsDMultiValue:      true,
+	}, clifecycletest.New(), clogger.NewNoop())
+	assert.NoError(t, err)
+
+	queue := map[string]string{"queue": "emails"}
+
+	metrics.CounterInc("jobs_total", queue)
+	metrics.CounterAdd("jobs_total", queue, 4)
+	metrics.CounterAdd("jobs_total", queue, -1)
+	metrics.GaugeSet("queue_depth", queue, 10)
+	metrics.GaugeAdd("queue_depth", queue, -3)
+	metrics.GaugeAdd("workers", nil, -2)
+	metrics.GaugeSet("temperature", nil, -5)
+	metrics.HistogramObserve("job_duration_seconds", nil, 0.25)
+	metrics.SummaryObserve("job_duration_seconds", nil, 1.5)
+	metrics.CounterInc("jobs:failed|retried", nil)
+
+	assert.NoError(t, metrics.Flush())
+
+	packets := readStatsDPackets(t, conn)
+	assert.Len(t, packets, 1)
+
+	assert.Equal(t, []string{
+		"test.job_duration_seconds:0.25:1.5|d|#env:test",
+		"test.jobs_failed_retried:1|c|#env:test",
+		"test.jobs_total:5|c|#env:test,queue:emails",
+		"test.queue_depth:7|g|#env:test,queue:emails",
+		"test.temperature:-5|g|#env:test",
+		"test.workers:-2|g|#env:test",
+	}, statsDLines(packets))
+
+	// Gauges keep their value across flushes
+	metrics.GaugeAdd("workers", nil, 3)
+
+	assert.NoError(t, metrics.Flush())
+	assert.Equal(t, []string{
+		"test.queue_depth:7|g|#env:test,queue:emails",
+		"test.temperature:-5|g|#env:test",
+		"test.workers:1|g|#env:test",
+	}, statsDLines(readStatsDPackets(t, conn)))
+}
+
+func TestStatsDMetrics_MaxPacketSize(t *testing.T) {
+	t.Parallel()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	metrics, err := cmetrics.NewStatsDMetrics(cmetrics.Config{
+		StatsDAddr:            conn.LocalAddr().String(),
+		StatsDFlushIntervalMS: 3600000,
+		StatsDMaxPacketSize:   64,
+		StatsDMultiValue:      true,
+	}, clifecycletest.New(), clogger.NewNoop())
+	assert.NoError(t, err)
+
+	for i := 0; i < 20; i++ {
+		metrics.HistogramObserve("job_duration_seconds", nil, float64(i))
+	}
+
+	assert.NoError(t, metrics.Flush())
+
+	packets := readStatsDPackets(t, conn)
+	assert.Greater(t, len(packets), 1)
+
+	for _, p := range packets {
+		assert.LessOrEqual(t, len(p), 64)
+	}
+
+	assert.Len(t, statsDValues(statsDLines(packets)), 20)
+	assert.True(t, strings.HasSuffix(statsDLines(packets)[0], "|ms"))
+}
+
+func TestStatsDMetrics_SingleValue(t *testing.T) {
+	t.Parallel()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	metrics, err := cmetrics.NewStatsDMetrics(cmetrics.Config{
+		StatsDAddr:            conn.LocalAddr().String(),
+		StatsDFlushIntervalMS: 3600000,
+	}, clifecycletest.New(), clogger.NewNoop())
+	assert.NoError(t, err)
+
+	metrics.HistogramObserve("job_duration_seconds", nil, 0.25)
+	metrics.HistogramObserve("job_duration_seconds", nil, 1.5)
+
+	assert.NoError(t, metrics.Flush())
+	assert.Equal(t, []string{
+		"job_duration_seconds:0.25|ms",
+		"job_duration_seconds:1.5|ms",
+	}, statsDLines(readStatsDPackets(t, conn)))
+}
+
+func TestStatsDMetrics_GaugeExpiry(t *testing.T) {
+	t.Parallel()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	metrics, err := cmetrics.NewStatsDMetrics(cmetrics.Config{
+		StatsDAddr:               conn.LocalAddr().String(),
+		StatsDFlushIntervalMS:    3600000,
+		StatsDGaugeExpiryFlushes: 2,
+	}, clifecycletest.New(), clogger.NewNoop())
+	assert.NoError(t, err)
+
+	metrics.GaugeSet("queue_depth", nil, 7)
+	metrics.GaugeSet("workers", nil, 2)
+
+	assert.NoError(t, metrics.Flush())
+	assert.Equal(t, []string{"queue_depth:7|g", "workers:2|g"}, statsDLines(readStatsDPackets(t, conn)))
+
+	metrics.GaugeAdd("workers", nil, 1)
+	metrics.GaugeDelete("queue_depth", nil)
+
+	assert.NoError(t, metrics.Flush())
+	assert.Equal(t, []string{"workers:3|g"}, statsDLines(readStatsDPackets(t, conn)))
+
+	assert.NoError(t, metrics.Flush())
+	assert.Equal(t, []string{"workers:3|g"}, statsDLines(readStatsDPackets(t, conn)))
+
+	// workers has not been updated for 2 flushes
+	assert.NoError(t, metrics.Flush())
+	assert.Empty(t, readStatsDPackets(t, conn))
+}
+
+func TestStatsDMetrics_SampledHistogram(t *testing.T) {
+	t.Parallel()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	metrics, err := cmetrics.NewStatsDMetrics(cmetrics.Config{
+		StatsDAddr:            conn.LocalAddr().String(),
+		StatsDFlushIntervalMS: 3600000,
+	}, clifecycletest.New(), clogger.NewNoop())
+	assert.NoError(t, err)
+
+	for i := 0; i < 5000; i++ {
+		metrics.HistogramObserve("job_duration_seconds", nil, float64(i))
+	}
+
+	assert.NoError(t, metrics.Flush())
+
+	lines := statsDLines(readStatsDPackets(t, conn))
+	for _, line := range lines {
+		assert.True(t, strings.HasSuffix(line, "|ms|@0.2000"), line)
+	}
+
+	assert.Len(t, statsDValues(lines), 1000)
+}
+
+func TestStatsDMetrics_UnixgramFlushOnStop(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "statsd.sock")
+
+	conn, err := net.ListenPacket("unixgram", path)
+	assert.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	lc := clifecycletest.New()
+
+	metrics, err := cmetrics.NewConfiguredMetrics(cmetrics.NewConfiguredMetricsParams{
+		Registry: cmetrics.NewRegistry(cmetrics.NewRegistryParams{}),
+		Config: cmetrics.Config{
+			Backend:               cmetrics.BackendStatsD,
+			StatsDNetwork:         "unixgram",
+			StatsDAddr:            path,
+			StatsDFlushIntervalMS: 3600000,
+		},
+		Prometheus: prometheus.NewRegistry(),
+		Lifecycle:  lc,
+		Logger:     clogger.NewNoop(),
+	})
+	assert.NoError(t, err)
+
+	metrics.CounterInc("jobs_total", nil)
+
+	assert.NoError(t, lc.Stop(clogger.NewNoop()))
+	assert.Equal(t, []string{"jobs_total:1|c"}, readStatsDPackets(t, conn))
+}
+
+func TestNewConfiguredMetrics_StatsDTypedMetrics(t *testing.T) {
+	t.Parallel()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	var (
+		jobs         = cmetrics.NewCounter[testJobLabels]("test_statsd_typed_jobs_total")
+		depth        = cmetrics.NewGauge[cmetrics.NoLabels]("test_statsd_typed_queue_depth")
+		promRegistry = prometheus.NewRegistry()
+	)
+
+	metrics, err := cmetrics.NewConfiguredMetrics(cmetrics.NewConfiguredMetricsParams{
+		Registry: cmetrics.NewRegistry(cmetrics.NewRegistryParams{
+			Handles: []cmetrics.Handle{jobs, depth},
+		}),
+		Config: cmetrics.Config{
+			Backend:               cmetrics.BackendStatsD,
+			StatsDAddr:            conn.LocalAddr().String(),
+			StatsDFlushIntervalMS: 3600000,
+		},
+		Prometheus: promRegistry,
+		Lifecycle:  clifecycletest.New(),
+		Logger:     clogger.NewNoop(),
+	})
+	assert.NoError(t, err)
+
+	jobs.Add(testJobLabels{Queue: "emails", Status: "succeeded"}, 2)
+	depth.Set(cmetrics.NoLabels{}, 3)
+
+	statsd, ok := metrics.(*cmetrics.StatsDMetrics)
+	assert.True(t, ok)
+	assert.NoError(t, statsd.Flush())
+
+	assert.Equal(t, []string{
+		"test_statsd_typed_jobs_total:2|c|#job_status:succeeded,queue:emails",
+		"test_statsd_typed_queue_depth:3|g",
+	}, statsDLines(readStatsDPackets(t, conn)))
+
+	families, err := promRegistry.Gather()
+	assert.NoError(t, err)
+	assert.Empty(t, families)
+}
+
+func TestNewConfiguredMetrics_InvalidBackend(t *testing.T) {
+	t.Parallel()
+
+	_, err := cmetrics.NewConfiguredMetrics(cmetrics.NewConfiguredMetricsParams{
+		Registry:   cmetrics.NewRegistry(cmetrics.NewRegistryParams{}),
+		Config:     cmetrics.Config{Backend: "unknown"},
+		Prometheus: prometheus.NewRegistry(),
+		Lifecycle:  clifecycletest.New(),
+		Logger:     clogger.NewNoop(),
+	})
+	assert.Error(t, err)
+}
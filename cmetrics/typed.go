@@ -0,0 +1,381 @@
+package cmetrics
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+
+	"github.com/gocopper/copper/cerrors"
+	"github.com/iancoleman/strcase"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Handle is a typed metric created with NewCounter, NewGauge, NewHistogram, or NewSummary. Handles are registered by
+// adding them to NewRegistryParams so that invalid names or labels fail NewMetrics at startup. With the statsd
+// backend, their values are sent through the configured Metrics instead.
+type Handle interface {
+	Name() string
+
+	register(r prometheus.Registerer) error
+	attach(m Metrics) error
+}
+
+// Register registers the handles with the given prometheus registerer. Packages that own their metrics, instead of
+// adding them to NewRegistryParams, can use it to register them with the app's prometheus registry.
+func Register(r prometheus.Registerer, handles ...Handle) error {
+	for _, h := range handles {
+		err := h.register(r)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NoLabels can be used as the labels of a typed metric that does not have any labels.
+type NoLabels struct{}
+
+// TypedCounter is a counter whose labels are the fields of L.
+type TypedCounter[L comparable] struct {
+	*handle[L, prometheus.Counter]
+}
+
+// TypedGauge is a gauge whose labels are the fields of L.
+type TypedGauge[L comparable] struct {
+	*handle[L, prometheus.Gauge]
+}
+
+// TypedHistogram is a histogram whose labels are the fields of L.
+type TypedHistogram[L comparable] struct {
+	*handle[L, prometheus.Observer]
+}
+
+// TypedSummary is a summary whose labels are the fields of L.
+type TypedSummary[L comparable] struct {
+	*handle[L, prometheus.Observer]
+}
+
+// NewCounter declares a counter with the labels defined by the string fields of L. The label names are the snake
+// case field names, unless they are set with a `label` struct tag. For example:
+//
+//	type HTTPLabels struct {
+//		StatusCode string `label:"status_code"`
+//		Path       string
+//	}
+//
+//	var httpRequests = cmetrics.NewCounter[HTTPLabels]("http_requests_total")
+//
+// The counter must be registered using NewRegistryParams before it is used. Until then, its methods do nothing and a
+// warning is printed to stderr the first time it is used.
+func NewCounter[L comparable](name string) *TypedCounter[L] {
+	return &TypedCounter[L]{newHandle[L](name, func(labelNames []string) metricVec[prometheus.Counter] {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{Name: name}, labelNames)
+	})}
+}
+
+// NewGauge declares a gauge with the labels defined by the string fields of L. See NewCounter for how labels are
+// defined.
+func NewGauge[L comparable](name string) *TypedGauge[L] {
+	return &TypedGauge[L]{newHandle[L](name, func(labelNames []string) metricVec[prometheus.Gauge] {
+		return prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name}, labelNames)
+	})}
+}
+
+// NewHistogram declares a histogram with the given buckets and the labels defined by the string fields of L. See
+// NewCounter for how labels are defined.
+func NewHistogram[L comparable](name string, buckets []float64) *TypedHistogram[L] {
+	return &TypedHistogram[L]{newHandle[L](name, func(labelNames []string) metricVec[prometheus.Observer] {
+		return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    name,
+			Buckets: buckets,
+		}, labelNames)
+	})}
+}
+
+// NewSummary declares a summary with the given objectives and the labels defined by the string fields of L. See
+// NewCounter for how labels are defined.
+func NewSummary[L comparable](name string, objectives map[float64]float64) *TypedSummary[L] {
+	return &TypedSummary[L]{newHandle[L](name, func(labelNames []string) metricVec[prometheus.Observer] {
+		return prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Name:       name,
+			Objectives: objectives,
+		}, labelNames)
+	})}
+}
+
+// Inc increments the counter with the given labels by 1.
+func (c *TypedCounter[L]) Inc(labels L) {
+	for _, m := range c.metrics(labels) {
+		m.Inc()
+	}
+
+	c.forward(labels, func(m Metrics, name string, labels map[string]string) {
+		m.CounterInc(name, labels)
+	})
+}
+
+// Add increments the counter with the given labels by the given value, which must not be negative.
+func (c *TypedCounter[L]) Add(labels L, value float64) {
+	if value < 0 {
+		return
+	}
+
+	for _, m := range c.metrics(labels) {
+		m.Add(value)
+	}
+
+	c.forward(labels, func(m Metrics, name string, labels map[string]string) {
+		m.CounterAdd(name, labels, value)
+	})
+}
+
+// Set sets the gauge with the given labels to the given value.
+func (g *TypedGauge[L]) Set(labels L, value float64) {
+	for _, m := range g.metrics(labels) {
+		m.Set(value)
+	}
+
+	g.forward(labels, func(m Metrics, name string, labels map[string]string) {
+		m.GaugeSet(name, labels, value)
+	})
+}
+
+// Add adds the given value, which may be negative, to the gauge with the given labels.
+func (g *TypedGauge[L]) Add(labels L, value float64) {
+	for _, m := range g.metrics(labels) {
+		m.Add(value)
+	}
+
+	g.forward(labels, func(m Metrics, name string, labels map[string]string) {
+		m.GaugeAdd(name, labels, value)
+	})
+}
+
+// Observe adds the value to the histogram with the given labels.
+func (h *TypedHistogram[L]) Observe(labels L, value float64) {
+	for _, m := range h.metrics(labels) {
+		m.Observe(value)
+	}
+
+	h.forward(labels, func(m Metrics, name string, labels map[string]string) {
+		m.HistogramObserve(name, labels, value)
+	})
+}
+
+// Observe adds the value to the summary with the given labels.
+func (s *TypedSummary[L]) Observe(labels L, value float64) {
+	for _, m := range s.metrics(labels) {
+		m.Observe(value)
+	}
+
+	s.forward(labels, func(m Metrics, name string, labels map[string]string) {
+		m.SummaryObserve(name, labels, value)
+	})
+}
+
+// metricVec is implemented by the prometheus vectors that are used by the typed metrics.
+type metricVec[M any] interface {
+	prometheus.Collector
+	GetMetricWithLabelValues(values ...string) (M, error)
+}
+
+// handle holds the vectors of a typed metric, one for each registry it is registered with, along with its metrics for
+// each set of labels. The metrics are cached by their labels so that looking them up does not allocate. The values
+// are also forwarded to the Metrics that the handle is attached to (ex. the statsd backend).
+type handle[L comparable, M any] struct {
+	name   string
+	labels labelFields
+	newVec func(labelNames []string) metricVec[M]
+
+	mu    sync.RWMutex
+	vecs  []metricVec[M]
+	cache map[L][]M
+	sinks []Metrics
+
+	warnUnregistered sync.Once
+}
+
+func newHandle[L comparable, M any](name string, newVec func(labelNames []string) metricVec[M]) *handle[L, M] {
+	return &handle[L, M]{
+		name:   name,
+		labels: labelFieldsOf(reflect.TypeOf((*L)(nil)).Elem()),
+		newVec: newVec,
+		cache:  make(map[L][]M),
+	}
+}
+
+// Name returns the name of the metric.
+func (h *handle[L, M]) Name() string {
+	return h.name
+}
+
+// register creates a vector for the metric and registers it. A handle can be registered with multiple registries, in
+// which case each registry gets its own vector and the values are recorded in all of them.
+func (h *handle[L, M]) register(r prometheus.Registerer) error {
+	if h.labels.err != nil {
+		return cerrors.New(h.labels.err, "invalid metric labels", map[string]any{
+			"name": h.name,
+		})
+	}
+
+	vec := h.newVec(h.labels.names)
+
+	err := r.Register(vec)
+	if err != nil {
+		return cerrors.New(err, "failed to register metric", map[string]any{
+			"name": h.name,
+		})
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.vecs = append(h.vecs, vec)
+	h.cache = make(map[L][]M)
+
+	return nil
+}
+
+// attach forwards the values of the metric to m.
+func (h *handle[L, M]) attach(m Metrics) error {
+	if h.labels.err != nil {
+		return cerrors.New(h.labels.err, "invalid metric labels", map[string]any{
+			"name": h.name,
+		})
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.sinks = append(h.sinks, m)
+	h.cache = make(map[L][]M)
+
+	return nil
+}
+
+// forward calls fn with each of the Metrics that the handle is attached to and the labels as a map.
+func (h *handle[L, M]) forward(labels L, fn func(m Metrics, name string, labels map[string]string)) {
+	h.mu.RLock()
+	sinks := h.sinks
+	h.mu.RUnlock()
+
+	if len(sinks) == 0 {
+		return
+	}
+
+	var (
+		values    = h.labels.values(labels)
+		labelsMap = make(map[string]string, len(values))
+	)
+
+	for i, name := range h.labels.names {
+		labelsMap[name] = values[i]
+	}
+
+	for _, m := range sinks {
+		fn(m, h.name, labelsMap)
+	}
+}
+
+// metrics returns the metric with the given labels from each of the handle's vectors. It returns nil, and warns once,
+// if the handle has neither been registered nor attached.
+func (h *handle[L, M]) metrics(labels L) []M {
+	h.mu.RLock()
+	ms, ok := h.cache[labels]
+	h.mu.RUnlock()
+
+	if ok {
+		return ms
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if ms, ok = h.cache[labels]; ok {
+		return ms
+	}
+
+	if len(h.vecs) == 0 && len(h.sinks) > 0 {
+		h.cache[labels] = nil
+		return nil
+	}
+
+	if len(h.vecs) == 0 {
+		h.warnUnregistered.Do(func() {
+			fmt.Fprintf(os.Stderr, "cmetrics: metric %s is used before it is registered\n", h.name)
+		})
+
+		return nil
+	}
+
+	values := h.labels.values(labels)
+
+	ms = make([]M, 0, len(h.vecs))
+	for _, vec := range h.vecs {
+		m, err := vec.GetMetricWithLabelValues(values...)
+		if err != nil {
+			return nil
+		}
+
+		ms = append(ms, m)
+	}
+
+	h.cache[labels] = ms
+
+	return ms
+}
+
+// labelFields holds the label names of a labels struct along with the indexes of the fields that hold their values.
+type labelFields struct {
+	names   []string
+	indexes []int
+	err     error
+}
+
+func labelFieldsOf(t reflect.Type) labelFields {
+	if t.Kind() != reflect.Struct {
+		return labelFields{err: cerrors.New(nil, "labels must be a struct", map[string]any{
+			"type": t.String(),
+		})}
+	}
+
+	fields := labelFields{
+		names:   make([]string, 0, t.NumField()),
+		indexes: make([]int, 0, t.NumField()),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		if !f.IsExported() || f.Type.Kind() != reflect.String {
+			return labelFields{err: cerrors.New(nil, "labels must be exported string fields", map[string]any{
+				"field": f.Name,
+			})}
+		}
+
+		name := f.Tag.Get("label")
+		if name == "" {
+			name = strcase.ToSnake(f.Name)
+		}
+
+		fields.names = append(fields.names, name)
+		fields.indexes = append(fields.indexes, i)
+	}
+
+	return fields
+}
+
+func (f labelFields) values(labels any) []string {
+	var (
+		v      = reflect.ValueOf(labels)
+		values = make([]string, len(f.indexes))
+	)
+
+	for i, idx := range f.indexes {
+		values[i] = v.Field(idx).String()
+	}
+
+	return values
+}
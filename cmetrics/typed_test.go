@@ -0,0 +1,120 @@
+package cmetrics_test
+
+import (
+	"testing"
+
+	"github.com/gocopper/copper/clogger"
+	"github.com/gocopper/copper/cmetrics"
+	"github.com/gocopper/copper/cmetrics/cmetricstest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+type testJobLabels struct {
+	Queue  string
+	Status string `label:"job_status"`
+}
+
+func TestTypedMetrics(t *testing.T) {
+	t.Parallel()
+
+	var (
+		jobs      = cmetrics.NewCounter[testJobLabels]("test_typed_jobs_total")
+		depth     = cmetrics.NewGauge[cmetrics.NoLabels]("test_typed_queue_depth")
+		duration  = cmetrics.NewHistogram[testJobLabels]("test_typed_job_duration_seconds", []float64{1, 5})
+		latency   = cmetrics.NewSummary[cmetrics.NoLabels]("test_typed_latency_seconds", nil)
+		succeeded = testJobLabels{Queue: "emails", Status: "succeeded"}
+	)
+
+	// Metrics are ignored until they are registered
+	jobs.Inc(succeeded)
+
+	_, registry := cmetricstest.New(t, cmetrics.NewRegistryParams{
+		Handles: []cmetrics.Handle{jobs, depth, duration, latency},
+	})
+
+	jobs.Inc(succeeded)
+	jobs.Add(succeeded, 2)
+	jobs.Inc(testJobLabels{Queue: "emails", Status: "failed"})
+
+	depth.Set(cmetrics.NoLabels{}, 10)
+	depth.Add(cmetrics.NoLabels{}, -4)
+
+	duration.Observe(succeeded, 2)
+	duration.Observe(succeeded, 3)
+
+	latency.Observe(cmetrics.NoLabels{}, 0.5)
+
+	cmetricstest.AssertValue(t, registry, "test_typed_jobs_total",
+		map[string]string{"queue": "emails", "job_status": "succeeded"}, 3)
+	cmetricstest.AssertValue(t, registry, "test_typed_jobs_total",
+		map[string]string{"queue": "emails", "job_status": "failed"}, 1)
+	cmetricstest.AssertValue(t, registry, "test_typed_queue_depth", nil, 6)
+	cmetricstest.AssertSampleCount(t, registry, "test_typed_job_duration_seconds",
+		map[string]string{"queue": "emails", "job_status": "succeeded"}, 2)
+	cmetricstest.AssertValue(t, registry, "test_typed_latency_seconds", nil, 0.5)
+}
+
+func TestTypedMetrics_NoAllocs(t *testing.T) { //nolint:paralleltest // AllocsPerRun cannot be used in parallel tests
+	var (
+		jobs   = cmetrics.NewCounter[testJobLabels]("test_typed_allocs_total")
+		labels = testJobLabels{Queue: "emails", Status: "succeeded"}
+	)
+
+	_, _ = cmetricstest.New(t, cmetrics.NewRegistryParams{
+		Handles: []cmetrics.Handle{jobs},
+	})
+
+	jobs.Inc(labels)
+
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		jobs.Inc(labels)
+	}))
+}
+
+func TestRegister_MultipleRegistries(t *testing.T) {
+	t.Parallel()
+
+	var (
+		jobs   = cmetrics.NewCounter[testJobLabels]("test_typed_multi_jobs_total")
+		labels = testJobLabels{Queue: "emails", Status: "succeeded"}
+		first  = prometheus.NewRegistry()
+		second = prometheus.NewRegistry()
+	)
+
+	assert.NoError(t, cmetrics.Register(first, jobs))
+
+	jobs.Inc(labels)
+
+	assert.NoError(t, cmetrics.Register(second, jobs))
+
+	jobs.Inc(labels)
+
+	cmetricstest.AssertValue(t, first, "test_typed_multi_jobs_total",
+		map[string]string{"queue": "emails", "job_status": "succeeded"}, 2)
+	cmetricstest.AssertValue(t, second, "test_typed_multi_jobs_total",
+		map[string]string{"queue": "emails", "job_status": "succeeded"}, 1)
+}
+
+func TestNewMetrics_InvalidTypedLabels(t *testing.T) {
+	t.Parallel()
+
+	type invalidLabels struct {
+		Code int
+	}
+
+	_, err := cmetrics.NewMetrics(cmetrics.NewRegistry(cmetrics.NewRegistryParams{
+		Handles: []cmetrics.Handle{cmetrics.NewCounter[invalidLabels]("test_invalid_total")},
+	}), prometheus.NewRegistry(), clogger.NewNoop())
+	assert.Error(t, err)
+
+	_, err = cmetrics.NewMetrics(cmetrics.NewRegistry(cmetrics.NewRegistryParams{
+		Handles: []cmetrics.Handle{cmetrics.NewCounter[string]("test_invalid_total")},
+	}), prometheus.NewRegistry(), clogger.NewNoop())
+	assert.Error(t, err)
+
+	_, err = cmetrics.NewMetrics(cmetrics.NewRegistry(cmetrics.NewRegistryParams{
+		Handles: []cmetrics.Handle{cmetrics.NewCounter[cmetrics.NoLabels]("test invalid name")},
+	}), prometheus.NewRegistry(), clogger.NewNoop())
+	assert.Error(t, err)
+}
@@ -1,7 +1,21 @@
 package cmetrics
 
-import "github.com/google/wire"
+import (
+	"github.com/gocopper/copper/chttp"
+	"github.com/google/wire"
+)
 
-var WireModule = wire.NewSet(
-	NewMetrics,
+// WireModule can be used as part of google/wire setup.
+var WireModule = wire.NewSet( //nolint:gochecknoglobals
+	NewPrometheusRegistry,
+	wire.Struct(new(NewConfiguredMetricsParams), "*"),
+	NewConfiguredMetrics,
+	wire.Bind(new(chttp.RequestMetrics), new(Metrics)),
+	LoadConfig,
+	wire.Struct(new(NewRouterParams), "*"),
+	NewRouter,
+	wire.Struct(new(NewServerParams), "*"),
+	NewServer,
+	wire.Struct(new(NewOTLPExporterParams), "*"),
+	NewOTLPExporter,
 )
@@ -0,0 +1,34 @@
+package creport
+
+import (
+	"github.com/gocopper/copper/cconfig"
+	"github.com/gocopper/copper/cerrors"
+)
+
+// Sinks supported by NewSink
+const (
+	SinkFile    = "file"
+	SinkWebhook = "webhook"
+)
+
+// LoadConfig loads Config from app's config
+func LoadConfig(appConfig cconfig.Loader) (Config, error) {
+	var config Config
+
+	err := appConfig.Load("creport", &config)
+	if err != nil {
+		return Config{}, cerrors.New(err, "failed to load creport config", nil)
+	}
+
+	return config, nil
+}
+
+// Config holds the params needed to configure Reporter. If sink is not set, reporting is disabled. A queue size of 0
+// uses the default of 100.
+type Config struct {
+	Sink                  string `toml:"sink"`
+	FilePath              string `toml:"file_path" default:"errors.jsonl"`
+	WebhookURL            string `toml:"webhook_url"`
+	RepeatIntervalSeconds uint   `toml:"repeat_interval_seconds" default:"60"`
+	QueueSize             uint   `toml:"queue_size" default:"100"`
+}
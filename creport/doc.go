@@ -0,0 +1,10 @@
+// Package creport reports errors logged at the error level, along with panics, to an error-reporting sink. Errors are
+// grouped by a fingerprint of their message chain and stack so that repeats of the same error are reported at most
+// once per configured interval.
+//
+// Reporter implements clogger.Hook and can be provided as one of the app's logger hooks:
+//
+//	func NewLoggerHooks(reporter *creport.Reporter) []clogger.Hook {
+//	  return []clogger.Hook{reporter}
+//	}
+package creport
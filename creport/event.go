@@ -0,0 +1,21 @@
+package creport
+
+import (
+	"time"
+
+	"github.com/gocopper/copper/cerrors"
+)
+
+// Event is a single error report that is sent to a Sink.
+type Event struct {
+	Fingerprint string         `json:"fingerprint"`
+	Level       string         `json:"level"`
+	Message     string         `json:"message"`
+	Tags        map[string]any `json:"tags,omitempty"`
+	Error       cerrors.Chain  `json:"error,omitempty"`
+
+	// Occurrences is the number of times the error occurred since it was last reported, including this occurrence.
+	Occurrences int       `json:"occurrences"`
+	FirstSeen   time.Time `json:"first_seen"`
+	LastSeen    time.Time `json:"last_seen"`
+}
@@ -0,0 +1,36 @@
+package creport
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/gocopper/copper/cerrors"
+)
+
+// fingerprint groups errors that are likely to have the same root cause. It is derived from the log message along
+// with each error's message and the location where it was created.
+func fingerprint(msg string, chain cerrors.Chain) string {
+	const fingerprintLen = 16
+
+	h := sha256.New()
+
+	_, _ = h.Write([]byte(msg))
+	writeChain(h, chain)
+
+	return hex.EncodeToString(h.Sum(nil))[:fingerprintLen]
+}
+
+func writeChain(h interface{ Write(p []byte) (int, error) }, chain cerrors.Chain) {
+	for _, link := range chain {
+		_, _ = h.Write([]byte("\n" + link.Message))
+
+		for _, frame := range link.Stack {
+			_, _ = h.Write([]byte("\n" + frame.Function + ":" + strconv.Itoa(frame.Line)))
+		}
+
+		for _, joined := range link.Joined {
+			writeChain(h, joined)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package creport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gocopper/copper/cerrors"
+	"github.com/gocopper/copper/clifecycle"
+	"github.com/gocopper/copper/clogger"
+)
+
+// maxTrackedFingerprints bounds the number of fingerprints kept in memory to deduplicate errors. Once reached,
+// fingerprints that are outside the repeat interval are forgotten.
+const maxTrackedFingerprints = 1000
+
+type (
+	// NewReporterParams holds the params needed to create a Reporter. The logger config is used to redact the panics,
+	// which are reported without going through the logger.
+	NewReporterParams struct {
+		Lifecycle    *clifecycle.Lifecycle
+		Sink         Sink
+		Config       Config
+		LoggerConfig clogger.Config
+	}
+
+	// Reporter is a clogger.Hook that reports errors logged at the error level to a Sink. Repeats of an error (as
+	// determined by its fingerprint) are reported at most once per the configured repeat interval along with the
+	// number of occurrences.
+	Reporter struct {
+		sink     Sink
+		enabled  bool
+		interval time.Duration
+
+		mu     sync.Mutex
+		seen   map[string]*occurrence
+		stats  Stats
+		closed bool
+		events chan Event
+		done   chan struct{}
+	}
+
+	// Stats holds the counts of errors handled by Reporter
+	Stats struct {
+		Reported   int
+		Suppressed int
+		Dropped    int
+		Failed     int
+	}
+
+	occurrence struct {
+		firstSeen  time.Time
+		reportedAt time.Time
+		count      int
+	}
+)
+
+// NewReporter creates a Reporter and starts sending reports in the background. Panics in goroutines started with
+// clifecycle.Lifecycle's Go are reported as well. Pending reports are sent when the app stops.
+func NewReporter(p NewReporterParams) (*Reporter, error) {
+	const DefaultQueueSize = 100
+
+	redact, err := clogger.NewRecordRedactor(p.LoggerConfig)
+	if err != nil {
+		return nil, cerrors.New(err, "failed to create record redactor", nil)
+	}
+
+	queueSize := p.Config.QueueSize
+	if queueSize == 0 {
+		queueSize = DefaultQueueSize
+	}
+
+	r := &Reporter{
+		sink:     p.Sink,
+		enabled:  p.Config.Sink != "",
+		interval: time.Duration(p.Config.RepeatIntervalSeconds) * time.Second,
+		seen:     make(map[string]*occurrence),
+		events:   make(chan Event, queueSize),
+		done:     make(chan struct{}),
+	}
+
+	go r.run()
+
+	p.Lifecycle.OnPanic(func(err error) {
+		rec := redact(clogger.Record{Level: clogger.LevelError, Msg: clifecycle.PanicMsg, Err: err})
+
+		r.report(rec.Level, rec.Msg, rec.Tags, rec.Err)
+	})
+	p.Lifecycle.OnStop(r.Close)
+
+	return r, nil
+}
+
+// OnLog implements clogger.Hook. Logs below the error level are ignored, and so are the panics logged by
+// clifecycle.Lifecycle (tagged with clifecycle.PanicTag) since they are reported with OnPanic.
+func (r *Reporter) OnLog(level clogger.Level, msg string, tags map[string]any, err error) {
+	if isPanic, _ := tags[clifecycle.PanicTag].(bool); isPanic {
+		return
+	}
+
+	r.report(level, msg, tags, err)
+}
+
+func (r *Reporter) report(level clogger.Level, msg string, tags map[string]any, err error) {
+	if !r.enabled || level < clogger.LevelError {
+		return
+	}
+
+	var (
+		now   = time.Now()
+		chain = cerrors.ChainOf(err)
+		fp    = fingerprint(msg, chain)
+	)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return
+	}
+
+	occ, ok := r.seen[fp]
+	if !ok {
+		r.forgetExpired(now)
+
+		occ = &occurrence{firstSeen: now}
+		r.seen[fp] = occ
+	}
+
+	occ.count++
+
+	if ok && now.Sub(occ.reportedAt) < r.interval {
+		r.stats.Suppressed++
+		return
+	}
+
+	event := Event{
+		Fingerprint: fp,
+		Level:       level.String(),
+		Message:     msg,
+		Tags:        tags,
+		Error:       chain,
+		Occurrences: occ.count,
+		FirstSeen:   occ.firstSeen,
+		LastSeen:    now,
+	}
+
+	select {
+	case r.events <- event:
+		occ.count = 0
+		occ.reportedAt = now
+	default:
+		r.stats.Dropped++
+	}
+}
+
+// Stats returns the counts of errors that have been handled so far.
+func (r *Reporter) Stats() Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.stats
+}
+
+// Close stops accepting new errors and waits for pending reports to be sent until the given context is done.
+func (r *Reporter) Close(ctx context.Context) error {
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.events)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return cerrors.New(ctx.Err(), "failed to send pending error reports", nil)
+	}
+}
+
+func (r *Reporter) run() {
+	const SendTimeout = 10 * time.Second
+
+	defer close(r.done)
+
+	for event := range r.events {
+		ctx, cancel := context.WithTimeout(context.Background(), SendTimeout)
+		err := r.sink.Send(ctx, event)
+		cancel()
+
+		r.mu.Lock()
+		if err != nil {
+			r.stats.Failed++
+		} else {
+			r.stats.Reported++
+		}
+		r.mu.Unlock()
+	}
+}
+
+// forgetExpired removes fingerprints that are outside the repeat interval and have no suppressed occurrences once
+// the number of tracked fingerprints reaches maxTrackedFingerprints.
+func (r *Reporter) forgetExpired(now time.Time) {
+	if len(r.seen) < maxTrackedFingerprints {
+		return
+	}
+
+	for fp, occ := range r.seen {
+		if occ.count == 0 && now.Sub(occ.reportedAt) >= r.interval {
+			delete(r.seen, fp)
+		}
+	}
+}
@@ -0,0 +1,342 @@
+package creport_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/gocopper/copper/cerrors"
+	"github.com/gocopper/copper/clifecycle"
+	"github.com/gocopper/copper/clifecycle/clifecycletest"
+	"github.com/gocopper/copper/clogger"
+	"github.com/gocopper/copper/creport"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReporter_Webhook(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		events = make([]creport.Event, 0)
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event creport.Event
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	var (
+		lc       = clifecycletest.New()
+		config   = creport.Config{Sink: creport.SinkWebhook, RepeatIntervalSeconds: 60, QueueSize: 10}
+		reporter = newReporter(t, creport.NewReporterParams{
+			Lifecycle: lc,
+			Sink:      creport.NewWebhookSink(server.URL),
+			Config:    config,
+		})
+		logger = clogger.NewWithWriters(&nopWriter{}, &nopWriter{}, clogger.FormatPlain, nil, nil, []clogger.Hook{reporter})
+	)
+
+	for i := 0; i < 3; i++ {
+		logger.Error("Failed to save user", cerrors.New(nil, "test-err", map[string]any{"id": i}))
+	}
+
+	logger.Error("Failed to save user", cerrors.New(nil, "other-err", nil))
+	logger.Warn("Failed to save user", cerrors.New(nil, "warn-err", nil))
+
+	assert.NoError(t, lc.Stop(clogger.NewNoop()))
+
+	assert.Len(t, events, 2)
+	assert.Equal(t, "Failed to save user", events[0].Message)
+	assert.Equal(t, "ERROR", events[0].Level)
+	assert.Equal(t, "test-err", events[0].Error[0].Message)
+	assert.Equal(t, 1, events[0].Occurrences)
+	assert.Equal(t, "other-err", events[1].Error[0].Message)
+	assert.NotEqual(t, events[0].Fingerprint, events[1].Fingerprint)
+
+	assert.Equal(t, creport.Stats{Reported: 2, Suppressed: 2}, reporter.Stats())
+}
+
+func TestReporter_File(t *testing.T) {
+	t.Parallel()
+
+	var (
+		lc   = clifecycletest.New()
+		path = filepath.Join(t.TempDir(), "errors.jsonl")
+	)
+
+	sink, err := creport.NewSink(creport.Config{Sink: creport.SinkFile, FilePath: path})
+	assert.NoError(t, err)
+
+	reporter := newReporter(t, creport.NewReporterParams{
+		Lifecycle: lc,
+		Sink:      sink,
+		Config:    creport.Config{Sink: creport.SinkFile, QueueSize: 10},
+	})
+
+	reporter.OnLog(clogger.LevelError, "test-msg-1", nil, errors.New("test-err")) //nolint:goerr113
+	reporter.OnLog(clogger.LevelError, "test-msg-2", nil, nil)
+
+	assert.NoError(t, reporter.Close(context.Background()))
+
+	f, err := os.Open(path)
+	assert.NoError(t, err)
+
+	defer func() { assert.NoError(t, f.Close()) }()
+
+	lines := 0
+	for scanner := bufio.NewScanner(f); scanner.Scan(); lines++ {
+		var event creport.Event
+
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+	}
+
+	assert.Equal(t, 2, lines)
+}
+
+func TestReporter_Panic(t *testing.T) {
+	t.Parallel()
+
+	var (
+		lc   = clifecycletest.New()
+		sink = &recordingSink{}
+	)
+
+	newReporter(t, creport.NewReporterParams{
+		Lifecycle: lc,
+		Sink:      sink,
+		Config:    creport.Config{Sink: "test", QueueSize: 10},
+	})
+
+	didPanic := make(chan struct{})
+	lc.OnPanic(func(err error) {
+		close(didPanic)
+	})
+
+	lc.Go(func(ctx context.Context) {
+		panic("test-panic")
+	})
+
+	<-didPanic
+
+	assert.NoError(t, lc.Stop(clogger.NewNoop()))
+
+	assert.Len(t, sink.events, 1)
+	assert.Equal(t, "test-panic", sink.events[0].Error[0].Message)
+	assert.NotEmpty(t, sink.events[0].Error[0].Stack)
+}
+
+func TestReporter_Webhook_Redacted(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		bodies = make([]string, 0)
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	var (
+		lc       = clifecycletest.New()
+		dir      = t.TempDir()
+		reporter = newReporter(t, creport.NewReporterParams{
+			Lifecycle: lc,
+			Sink:      creport.NewWebhookSink(server.URL),
+			Config:    creport.Config{Sink: creport.SinkWebhook, RepeatIntervalSeconds: 60, QueueSize: 10},
+		})
+	)
+
+	logger, err := clogger.New(clogger.Config{
+		Out:            filepath.Join(dir, "out.log"),
+		Err:            filepath.Join(dir, "err.log"),
+		RedactFields:   []string{"password"},
+		RedactPatterns: []string{"bearer"},
+	}, []clogger.Hook{reporter})
+	assert.NoError(t, err)
+
+	logger.WithTags(map[string]any{
+		"password": "test-password",
+	}).Error("Failed to sign in with Bearer test-token", cerrors.New(nil, "test-err", map[string]any{
+		"password": "test-err-password",
+	}))
+
+	assert.NoError(t, lc.Stop(clogger.NewNoop()))
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Len(t, bodies, 1)
+	assert.NotContains(t, bodies[0], "test-password")
+	assert.NotContains(t, bodies[0], "test-err-password")
+	assert.NotContains(t, bodies[0], "test-token")
+}
+
+func TestReporter_Panic_LoggerHook(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sink     = &recordingSink{}
+		reporter *creport.Reporter
+		hook     = hookFunc(func(level clogger.Level, msg string, tags map[string]any, err error) {
+			reporter.OnLog(level, msg, tags, err)
+		})
+		lc = clifecycle.New(clogger.NewWithWriters(io.Discard, io.Discard, clogger.FormatPlain, nil, nil,
+			[]clogger.Hook{hook}))
+	)
+
+	reporter = newReporter(t, creport.NewReporterParams{
+		Lifecycle: lc,
+		Sink:      sink,
+		Config:    creport.Config{Sink: "test", RepeatIntervalSeconds: 60, QueueSize: 10},
+	})
+
+	didPanic := make(chan struct{})
+	lc.OnPanic(func(err error) {
+		close(didPanic)
+	})
+
+	lc.Go(func(ctx context.Context) {
+		panic("test-panic")
+	})
+
+	<-didPanic
+
+	assert.NoError(t, lc.Stop(clogger.NewNoop()))
+
+	assert.Len(t, sink.events, 1)
+	assert.Equal(t, creport.Stats{Reported: 1}, reporter.Stats())
+}
+
+func TestReporter_RedactedFingerprint(t *testing.T) {
+	t.Parallel()
+
+	var (
+		lc       = clifecycletest.New()
+		dir      = t.TempDir()
+		sink     = &recordingSink{}
+		reporter = newReporter(t, creport.NewReporterParams{
+			Lifecycle: lc,
+			Sink:      sink,
+			Config:    creport.Config{Sink: "test", RepeatIntervalSeconds: 60},
+		})
+	)
+
+	logger, err := clogger.New(clogger.Config{
+		Out:            filepath.Join(dir, "out.log"),
+		Err:            filepath.Join(dir, "err.log"),
+		RedactPatterns: []string{"email"},
+	}, []clogger.Hook{reporter})
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		logger.Error("Failed to save user", cerrors.WithKind(cerrors.New(nil, "test-err", map[string]any{
+			"id":    i,
+			"email": "test@example.com",
+		}), cerrors.KindConflict))
+	}
+
+	assert.NoError(t, lc.Stop(clogger.NewNoop()))
+
+	assert.Equal(t, creport.Stats{Reported: 1, Suppressed: 2}, reporter.Stats())
+	assert.Equal(t, "test-err", sink.events[0].Error[0].Message)
+	assert.Equal(t, cerrors.KindConflict, sink.events[0].Error[0].Kind)
+	assert.NotEmpty(t, sink.events[0].Error[0].Stack)
+	assert.NotEqual(t, "test@example.com", sink.events[0].Error[0].Tags["email"])
+}
+
+func TestReporter_Panic_Redacted(t *testing.T) {
+	t.Parallel()
+
+	var (
+		lc   = clifecycletest.New()
+		sink = &recordingSink{}
+	)
+
+	newReporter(t, creport.NewReporterParams{
+		Lifecycle:    lc,
+		Sink:         sink,
+		Config:       creport.Config{Sink: "test"},
+		LoggerConfig: clogger.Config{RedactPatterns: []string{"bearer"}},
+	})
+
+	didPanic := make(chan struct{})
+	lc.OnPanic(func(err error) {
+		close(didPanic)
+	})
+
+	lc.Go(func(ctx context.Context) {
+		panic("failed to authorize Bearer test-token")
+	})
+
+	<-didPanic
+
+	assert.NoError(t, lc.Stop(clogger.NewNoop()))
+
+	assert.Len(t, sink.events, 1)
+	assert.NotContains(t, sink.events[0].Error[0].Message, "test-token")
+	assert.NotEmpty(t, sink.events[0].Error[0].Stack)
+}
+
+func TestNewSink_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := creport.NewSink(creport.Config{Sink: "invalid"})
+	assert.Error(t, err)
+}
+
+func newReporter(t *testing.T, p creport.NewReporterParams) *creport.Reporter {
+	t.Helper()
+
+	reporter, err := creport.NewReporter(p)
+	assert.NoError(t, err)
+
+	return reporter
+}
+
+type hookFunc func(level clogger.Level, msg string, tags map[string]any, err error)
+
+func (f hookFunc) OnLog(level clogger.Level, msg string, tags map[string]any, err error) {
+	f(level, msg, tags, err)
+}
+
+type recordingSink struct {
+	mu     sync.Mutex
+	events []creport.Event
+}
+
+func (s *recordingSink) Send(_ context.Context, event creport.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, event)
+
+	return nil
+}
+
+type nopWriter struct{}
+
+func (w *nopWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
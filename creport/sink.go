@@ -0,0 +1,122 @@
+package creport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gocopper/copper/cerrors"
+)
+
+// Sink receives error reports from Reporter.
+type Sink interface {
+	Send(ctx context.Context, event Event) error
+}
+
+// NewSink creates the Sink configured by the sink config key. If no sink is configured, a Sink that discards all
+// events is returned.
+func NewSink(config Config) (Sink, error) {
+	switch config.Sink {
+	case SinkFile:
+		return NewFileSink(config.FilePath), nil
+	case SinkWebhook:
+		return NewWebhookSink(config.WebhookURL), nil
+	case "":
+		return &noopSink{}, nil
+	default:
+		return nil, cerrors.New(nil, "invalid sink", map[string]any{
+			"sink": config.Sink,
+		})
+	}
+}
+
+// NewFileSink creates a Sink that appends each event as a JSON line to the file at the given path.
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+// FileSink is a Sink that writes events to a local JSONL file.
+type FileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+// Send appends the event to the file as a single JSON line.
+func (s *FileSink) Send(_ context.Context, event Event) error {
+	const FilePerms = 0666
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, FilePerms)
+	if err != nil {
+		return cerrors.New(err, "failed to open report file", map[string]any{
+			"path": s.path,
+		})
+	}
+	defer func() { _ = f.Close() }()
+
+	err = json.NewEncoder(f).Encode(event)
+	if err != nil {
+		return cerrors.New(err, "failed to write event", map[string]any{
+			"path": s.path,
+		})
+	}
+
+	return nil
+}
+
+// NewWebhookSink creates a Sink that posts each event as JSON to the given URL.
+func NewWebhookSink(url string) *WebhookSink {
+	const DefaultTimeout = 10 * time.Second
+
+	return &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+// WebhookSink is a Sink that sends events to a generic HTTP webhook.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// Send posts the event to the webhook URL. Any non-2xx response is treated as an error.
+func (s *WebhookSink) Send(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return cerrors.New(err, "failed to marshal event", nil)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return cerrors.New(err, "failed to create webhook request", nil)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return cerrors.New(err, "failed to send webhook request", nil)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return cerrors.New(nil, "webhook responded with a non-2xx status code", map[string]any{
+			"status_code": resp.StatusCode,
+		})
+	}
+
+	return nil
+}
+
+type noopSink struct{}
+
+func (s *noopSink) Send(context.Context, Event) error {
+	return nil
+}
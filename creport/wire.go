@@ -0,0 +1,11 @@
+package creport
+
+import "github.com/google/wire"
+
+// WireModule can be used as part of google/wire setup.
+var WireModule = wire.NewSet( //nolint:gochecknoglobals
+	LoadConfig,
+	NewSink,
+	wire.Struct(new(NewReporterParams), "*"),
+	NewReporter,
+)
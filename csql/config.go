@@ -49,6 +49,9 @@ type (
 		MaxOpenConnections  *int             `toml:"max_open_connections"`
 		MaxIdleConnections  *int             `toml:"max_idle_connections"`
 		ConnMaxLifetimeMins *int             `toml:"conn_max_lifetime_mins"`
+
+		// MetricsDBName is used as the db_name label of the csql metrics. It defaults to the dialect.
+		MetricsDBName string `toml:"metrics_db_name"`
 	}
 
 	// ConfigMigrations configures the migrations
@@ -58,6 +61,14 @@ type (
 	}
 )
 
+func (c Config) metricsDBName() string {
+	if c.MetricsDBName == "" {
+		return c.Dialect
+	}
+
+	return c.MetricsDBName
+}
+
 func (cm ConfigMigrations) sqlMigrateDirection() (migrate.MigrationDirection, error) {
 	switch strings.ToLower(cm.Direction) {
 	case MigrationsDirectionUp:
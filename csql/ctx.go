@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	"github.com/gocopper/copper/cerrors"
+	"github.com/gocopper/copper/clogger"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -59,6 +60,20 @@ func txFromCtxOrNil(ctx context.Context) *sqlx.Tx {
 	return tx
 }
 
+func init() { //nolint:gochecknoinits
+	// The extractor only depends on the context, so it is registered once for the process instead of per app
+	clogger.RegisterContextExtractor("csql", extractLogTags)
+}
+
+// extractLogTags is a clogger.ContextExtractor that marks logs written within a database transaction.
+func extractLogTags(ctx context.Context) map[string]any {
+	if txFromCtxOrNil(ctx) == nil {
+		return nil
+	}
+
+	return map[string]any{"db_tx": true}
+}
+
 // contextWithoutTx wraps a context and shadows the transaction key while preserving all other values.
 type contextWithoutTx struct {
 	context.Context
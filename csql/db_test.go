@@ -26,7 +26,7 @@ func TestNewDBConnection(t *testing.T) {
 
 	assert.NoError(t, db.Ping())
 
-	lc.Stop(logger)
+	assert.NoError(t, lc.Stop(logger))
 
 	assert.Error(t, db.Ping())
 }
@@ -0,0 +1,186 @@
+package csql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gocopper/copper/cerrors"
+	"github.com/gocopper/copper/cmetrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const queryNameCtxKey = ctxKey("csql/query-name")
+
+// unnamedQuery is used as the query label for queries that are run without a name
+const unnamedQuery = "unnamed"
+
+func init() { //nolint:gochecknoinits
+	// The metrics are defined once for the process so that each app does not have to add them to its registry
+	cmetrics.RegisterDefaults(cmetrics.NewRegistryParams{
+		Counters: []cmetrics.Counter{
+			{Name: "csql_tx_commits_total", Labels: []string{"db_name", "status"}},
+			{Name: "csql_tx_rollbacks_total", Labels: []string{"db_name", "status"}},
+		},
+		Histograms: []cmetrics.Histogram{
+			{
+				Name:    "csql_query_duration_seconds",
+				Labels:  []string{"db_name", "operation", "query", "status"},
+				Buckets: []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+			},
+		},
+	})
+}
+
+type (
+	// Metrics reports the query durations and the transaction commits and rollbacks to cmetrics.Metrics, and the
+	// database connection pool stats to the app's prometheus registry. The metrics are labeled with the db_name set
+	// in the config. A nil *Metrics does not report anything.
+	Metrics struct {
+		dbName  string
+		metrics cmetrics.Metrics
+	}
+
+	// NewMetricsParams holds the params needed to instantiate new Metrics
+	NewMetricsParams struct {
+		DB         *sql.DB
+		Config     Config
+		Metrics    cmetrics.Metrics
+		Prometheus *prometheus.Registry
+	}
+
+	// poolStatsCollector is a prometheus.Collector that reads the connection pool stats when the registry is
+	// gathered (ex. scraped).
+	poolStatsCollector struct {
+		db *sql.DB
+
+		maxOpenConnections *prometheus.Desc
+		openConnections    *prometheus.Desc
+		inUseConnections   *prometheus.Desc
+		idleConnections    *prometheus.Desc
+		waitCount          *prometheus.Desc
+		waitDuration       *prometheus.Desc
+		maxIdleClosed      *prometheus.Desc
+		maxIdleTimeClosed  *prometheus.Desc
+		maxLifetimeClosed  *prometheus.Desc
+	}
+)
+
+// NewMetrics creates Metrics that report to the given cmetrics.Metrics and registers the connection pool stats with
+// the app's prometheus registry. The pool stats are only available from the prometheus registry, regardless of the
+// cmetrics backend.
+func NewMetrics(p NewMetricsParams) (*Metrics, error) {
+	dbName := p.Config.metricsDBName()
+
+	err := p.Prometheus.Register(newPoolStatsCollector(p.DB, dbName))
+	if err != nil {
+		return nil, cerrors.New(err, "failed to register pool stats collector", map[string]any{
+			"db_name": dbName,
+		})
+	}
+
+	return &Metrics{
+		dbName:  dbName,
+		metrics: p.Metrics,
+	}, nil
+}
+
+func newPoolStatsCollector(db *sql.DB, dbName string) *poolStatsCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(name, help, nil, prometheus.Labels{"db_name": dbName})
+	}
+
+	return &poolStatsCollector{
+		db: db,
+
+		maxOpenConnections: desc("csql_pool_max_open_connections", "Maximum number of open connections."),
+		openConnections:    desc("csql_pool_open_connections", "Number of open connections."),
+		inUseConnections:   desc("csql_pool_in_use_connections", "Number of connections in use."),
+		idleConnections:    desc("csql_pool_idle_connections", "Number of idle connections."),
+		waitCount:          desc("csql_pool_wait_count_total", "Number of connections waited for."),
+		waitDuration: desc("csql_pool_wait_duration_seconds_total",
+			"Time spent waiting for new connections."),
+		maxIdleClosed: desc("csql_pool_max_idle_closed_total",
+			"Number of connections closed due to the max idle connections."),
+		maxIdleTimeClosed: desc("csql_pool_max_idle_time_closed_total",
+			"Number of connections closed due to the max idle time."),
+		maxLifetimeClosed: desc("csql_pool_max_lifetime_closed_total",
+			"Number of connections closed due to the max lifetime."),
+	}
+}
+
+func (c *poolStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *poolStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.db.Stats()
+
+	ch <- prometheus.MustNewConstMetric(c.maxOpenConnections, prometheus.GaugeValue, float64(stats.MaxOpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.openConnections, prometheus.GaugeValue, float64(stats.OpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.inUseConnections, prometheus.GaugeValue, float64(stats.InUse))
+	ch <- prometheus.MustNewConstMetric(c.idleConnections, prometheus.GaugeValue, float64(stats.Idle))
+	ch <- prometheus.MustNewConstMetric(c.waitCount, prometheus.CounterValue, float64(stats.WaitCount))
+	ch <- prometheus.MustNewConstMetric(c.waitDuration, prometheus.CounterValue, stats.WaitDuration.Seconds())
+	ch <- prometheus.MustNewConstMetric(c.maxIdleClosed, prometheus.CounterValue, float64(stats.MaxIdleClosed))
+	ch <- prometheus.MustNewConstMetric(c.maxIdleTimeClosed, prometheus.CounterValue, float64(stats.MaxIdleTimeClosed))
+	ch <- prometheus.MustNewConstMetric(c.maxLifetimeClosed, prometheus.CounterValue, float64(stats.MaxLifetimeClosed))
+}
+
+// WithQueryName returns a context that names the queries run with it. The name is used as the query label of the
+// csql_query_duration_seconds histogram.
+func WithQueryName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, queryNameCtxKey, name)
+}
+
+func queryNameFromCtx(ctx context.Context) string {
+	name, ok := ctx.Value(queryNameCtxKey).(string)
+	if !ok || name == "" {
+		return unnamedQuery
+	}
+
+	return name
+}
+
+func (m *Metrics) observeQuery(ctx context.Context, operation string, start time.Time, err error) {
+	if m == nil {
+		return
+	}
+
+	m.metrics.HistogramObserve("csql_query_duration_seconds", map[string]string{
+		"db_name":   m.dbName,
+		"operation": operation,
+		"query":     queryNameFromCtx(ctx),
+		"status":    metricStatus(err),
+	}, time.Since(start).Seconds())
+}
+
+func (m *Metrics) incCommits(err error) {
+	if m == nil {
+		return
+	}
+
+	m.metrics.CounterInc("csql_tx_commits_total", map[string]string{
+		"db_name": m.dbName,
+		"status":  metricStatus(err),
+	})
+}
+
+func (m *Metrics) incRollbacks(err error) {
+	if m == nil {
+		return
+	}
+
+	m.metrics.CounterInc("csql_tx_rollbacks_total", map[string]string{
+		"db_name": m.dbName,
+		"status":  metricStatus(err),
+	})
+}
+
+func metricStatus(err error) string {
+	if err != nil {
+		return "error"
+	}
+
+	return "ok"
+}
@@ -0,0 +1,115 @@
+package csql_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/gocopper/copper/clifecycle/clifecycletest"
+	"github.com/gocopper/copper/clogger"
+	"github.com/gocopper/copper/cmetrics"
+	"github.com/gocopper/copper/cmetrics/cmetricstest"
+	"github.com/gocopper/copper/csql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec("create table people (name text)")
+	assert.NoError(t, err)
+
+	var (
+		config               = csql.Config{Dialect: "sqlite3", MetricsDBName: "test-db"}
+		lc                   = clifecycletest.New()
+		appMetrics, registry = cmetricstest.New(t, cmetrics.NewRegistryParams{})
+	)
+
+	metrics, err := csql.NewMetrics(csql.NewMetricsParams{
+		DB:         db,
+		Config:     config,
+		Metrics:    appMetrics,
+		Prometheus: registry,
+	})
+	assert.NoError(t, err)
+
+	querier := csql.NewQuerierWithMetrics(csql.NewQuerierParams{
+		DB:        db,
+		Lifecycle: lc,
+		Config:    config,
+		Metrics:   metrics,
+		Logger:    clogger.NewNoop(),
+	})
+
+	ctx := csql.WithQueryName(context.Background(), "insert_person")
+
+	_, err = querier.Exec(ctx, "insert into people (name) values ('test')")
+	assert.NoError(t, err)
+
+	var dest struct {
+		Name string
+	}
+
+	err = querier.Get(context.Background(), &dest, "select * from people where name = 'unknown'")
+	assert.Error(t, err)
+
+	err = querier.Select(context.Background(), &[]string{}, "select * from unknown")
+	assert.Error(t, err)
+
+	err = querier.InTx(context.Background(), func(ctx context.Context) error {
+		_, err := querier.Exec(ctx, "insert into people (name) values ('test')")
+		return err
+	})
+	assert.NoError(t, err)
+
+	txCtx, tx, err := querier.CtxWithTx(context.Background())
+	assert.NoError(t, err)
+
+	_, err = querier.Exec(txCtx, "insert into people (name) values ('test')")
+	assert.NoError(t, err)
+	assert.NoError(t, querier.RollbackTx(tx))
+
+	cmetricstest.AssertSampleCount(t, registry, "csql_query_duration_seconds", map[string]string{
+		"operation": "exec",
+		"query":     "insert_person",
+		"db_name":   "test-db",
+		"status":    "ok",
+	}, 1)
+	cmetricstest.AssertSampleCount(t, registry, "csql_query_duration_seconds", map[string]string{
+		"operation": "get",
+		"query":     "unnamed",
+		"db_name":   "test-db",
+		"status":    "ok",
+	}, 1)
+	cmetricstest.AssertSampleCount(t, registry, "csql_query_duration_seconds", map[string]string{
+		"operation": "select",
+		"query":     "unnamed",
+		"db_name":   "test-db",
+		"status":    "error",
+	}, 1)
+	cmetricstest.AssertSampleCount(t, registry, "csql_query_duration_seconds", map[string]string{
+		"operation": "exec",
+		"query":     "unnamed",
+		"db_name":   "test-db",
+		"status":    "ok",
+	}, 2)
+
+	cmetricstest.AssertValue(t, registry, "csql_tx_commits_total", map[string]string{"db_name": "test-db", "status": "ok"}, 1)
+	cmetricstest.AssertValue(t, registry, "csql_tx_rollbacks_total", map[string]string{"db_name": "test-db", "status": "ok"}, 1)
+
+	cmetricstest.AssertValue(t, registry, "csql_pool_max_open_connections", map[string]string{"db_name": "test-db"}, 1)
+	cmetricstest.AssertValue(t, registry, "csql_pool_in_use_connections", map[string]string{"db_name": "test-db"}, 0)
+
+	// The pool stats are read when the registry is gathered
+	db.SetMaxOpenConns(2)
+
+	cmetricstest.AssertValue(t, registry, "csql_pool_max_open_connections", map[string]string{"db_name": "test-db"}, 2)
+
+	assert.NoError(t, lc.Stop(clogger.NewNoop()))
+}
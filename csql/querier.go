@@ -37,14 +37,35 @@ type Querier interface {
 	RollbackTx(tx *sql.Tx) error
 }
 
+// NewQuerierParams holds the params needed to instantiate a Querier that reports metrics
+type NewQuerierParams struct {
+	DB        *sql.DB
+	Lifecycle *clifecycle.Lifecycle
+	Config    Config
+	Metrics   *Metrics
+	Logger    clogger.Logger
+}
+
 // NewQuerier returns a querier using the given database connection and the dialect
 func NewQuerier(db *sql.DB, app *clifecycle.Lifecycle, config Config, logger clogger.Logger) Querier {
+	return NewQuerierWithMetrics(NewQuerierParams{
+		DB:        db,
+		Lifecycle: app,
+		Config:    config,
+		Logger:    logger,
+	})
+}
+
+// NewQuerierWithMetrics returns a querier like NewQuerier that also reports the durations of the queries and the
+// transaction commits and rollbacks to the given metrics.
+func NewQuerierWithMetrics(p NewQuerierParams) Querier {
 	return &querier{
-		db:            sqlx.NewDb(db, config.Dialect),
-		app:           app,
-		dialect:       config.Dialect,
+		db:            sqlx.NewDb(p.DB, p.Config.Dialect),
+		app:           p.Lifecycle,
+		dialect:       p.Config.Dialect,
 		in:            false,
-		logger:        logger,
+		metrics:       p.Metrics,
+		logger:        p.Logger,
 		mu:            &sync.RWMutex{},
 		callbacksByTx: make(map[*sql.Tx][]func(context.Context) error),
 	}
@@ -55,6 +76,7 @@ type querier struct {
 	app     *clifecycle.Lifecycle
 	dialect string
 	in      bool
+	metrics *Metrics
 	logger  clogger.Logger
 
 	mu            *sync.RWMutex
@@ -92,6 +114,7 @@ func (q *querier) OnCommit(ctx context.Context, cb func(context.Context) error)
 func (q *querier) CommitTx(tx *sql.Tx) error {
 	err := tx.Commit()
 	if err != nil && !errors.Is(err, sql.ErrTxDone) && !strings.Contains(err.Error(), "commit unexpectedly resulted in rollback") {
+		q.metrics.incCommits(err)
 		return err
 	}
 
@@ -99,6 +122,10 @@ func (q *querier) CommitTx(tx *sql.Tx) error {
 		q.logger.Warn(err.Error(), nil)
 	}
 
+	if !errors.Is(err, sql.ErrTxDone) {
+		q.metrics.incCommits(err)
+	}
+
 	q.mu.Lock()
 	callbacks, ok := q.callbacksByTx[tx]
 	delete(q.callbacksByTx, tx)
@@ -125,6 +152,9 @@ func (q *querier) CommitTx(tx *sql.Tx) error {
 
 func (q *querier) RollbackTx(tx *sql.Tx) error {
 	err := tx.Rollback()
+	if !errors.Is(err, sql.ErrTxDone) {
+		q.metrics.incRollbacks(err)
+	}
 
 	q.mu.Lock()
 	delete(q.callbacksByTx, tx)
@@ -179,6 +209,7 @@ func (q *querier) WithIn() Querier {
 		app:           q.app,
 		dialect:       q.dialect,
 		in:            true,
+		metrics:       q.metrics,
 		logger:        q.logger,
 		mu:            q.mu,
 		callbacksByTx: q.callbacksByTx,
@@ -198,7 +229,17 @@ func (q *querier) Get(ctx context.Context, dest any, query string, args ...any)
 		return err
 	}
 
-	return q.getExecutor(ctx).GetContext(ctx, dest, query, args...)
+	start := time.Now()
+
+	err = q.getExecutor(ctx).GetContext(ctx, dest, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		q.metrics.observeQuery(ctx, "get", start, nil)
+		return cerrors.WithKind(err, cerrors.KindNotFound)
+	}
+
+	q.metrics.observeQuery(ctx, "get", start, err)
+
+	return err
 }
 
 func (q *querier) Select(ctx context.Context, dest any, query string, args ...any) error {
@@ -207,7 +248,12 @@ func (q *querier) Select(ctx context.Context, dest any, query string, args ...an
 		return err
 	}
 
-	return q.getExecutor(ctx).SelectContext(ctx, dest, query, args...)
+	start := time.Now()
+
+	err = q.getExecutor(ctx).SelectContext(ctx, dest, query, args...)
+	q.metrics.observeQuery(ctx, "select", start, err)
+
+	return err
 }
 
 func (q *querier) Exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
@@ -216,7 +262,12 @@ func (q *querier) Exec(ctx context.Context, query string, args ...any) (sql.Resu
 		return nil, err
 	}
 
-	return q.getExecutor(ctx).ExecContext(ctx, query, args...)
+	start := time.Now()
+
+	res, err := q.getExecutor(ctx).ExecContext(ctx, query, args...)
+	q.metrics.observeQuery(ctx, "exec", start, err)
+
+	return res, err
 }
 
 func (q *querier) mkQueryWithArgs(ctx context.Context, query string, args []any) (string, []any, error) {
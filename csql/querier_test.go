@@ -1,10 +1,12 @@
 package csql_test
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"testing"
 
+	"github.com/gocopper/copper/cerrors"
 	"github.com/gocopper/copper/clifecycle/clifecycletest"
 	"github.com/gocopper/copper/clogger"
 	"github.com/gocopper/copper/csql"
@@ -144,3 +146,43 @@ func TestQuerier_CtxWithoutTx(t *testing.T) {
 	assert.Error(t, err, "CtxWithoutTx should remove transaction from context")
 	assert.Contains(t, err.Error(), "no database transaction in the context")
 }
+
+func TestQuerier_Get_NotFound(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+
+	_, err = db.Exec("create table people (name text);")
+	assert.NoError(t, err)
+
+	querier := csql.NewQuerier(db, clifecycletest.New(), csql.Config{Dialect: "sqlite3"}, clogger.NewNoop())
+
+	var dest struct {
+		Name string
+	}
+
+	err = querier.Get(context.Background(), &dest, "select * from people")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Equal(t, cerrors.KindNotFound, cerrors.KindOf(err))
+}
+
+func TestQuerier_LogTagsInTx(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+
+	querier := csql.NewQuerier(db, clifecycletest.New(), csql.Config{Dialect: "sqlite3"}, clogger.NewNoop())
+
+	ctx := clogger.WithContext(context.Background(), clogger.NewWithWriters(&buf, &buf, clogger.FormatPlain, nil, nil, nil))
+
+	assert.NoError(t, querier.InTx(ctx, func(ctx context.Context) error {
+		clogger.FromContext(ctx).Info("test-msg")
+		return nil
+	}))
+
+	assert.Contains(t, buf.String(), "db_tx=true")
+}
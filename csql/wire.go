@@ -5,10 +5,13 @@ import "github.com/google/wire"
 // WireModule can be used as part of google/wire setup.
 var WireModule = wire.NewSet(
 	NewDBConnection,
-	NewQuerier,
+	NewQuerierWithMetrics,
 	NewMigrator,
 	LoadConfig,
 	NewTxMiddleware,
+	NewMetrics,
 
 	wire.Struct(new(NewMigratorParams), "*"),
+	wire.Struct(new(NewQuerierParams), "*"),
+	wire.Struct(new(NewMetricsParams), "*"),
 )
@@ -13,6 +13,7 @@ require (
 	github.com/mattn/go-sqlite3 v1.14.18
 	github.com/pelletier/go-toml v1.9.3
 	github.com/prometheus/client_golang v1.20.3
+	github.com/prometheus/client_model v0.6.1
 	github.com/rubenv/sql-migrate v1.1.2
 	github.com/shopspring/decimal v1.2.0
 	github.com/stretchr/testify v1.9.0
@@ -29,12 +30,12 @@ require (
 	github.com/go-sql-driver/mysql v1.7.1 // indirect
 	github.com/huandu/xstrings v1.3.3 // indirect
 	github.com/imdario/mergo v0.3.12 // indirect
+	github.com/klauspost/compress v1.17.9 // indirect
 	github.com/lib/pq v1.10.2 // indirect
 	github.com/mitchellh/copystructure v1.0.0 // indirect
 	github.com/mitchellh/reflectwalk v1.0.0 // indirect
 	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
 	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
-	github.com/prometheus/client_model v0.6.1 // indirect
 	github.com/prometheus/common v0.59.1 // indirect
 	github.com/prometheus/procfs v0.15.1 // indirect
 	github.com/rogpeppe/go-internal v1.12.0 // indirect
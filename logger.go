@@ -0,0 +1,30 @@
+package copper
+
+import (
+	"context"
+	"io"
+
+	"github.com/gocopper/copper/clifecycle"
+	"github.com/gocopper/copper/clogger"
+)
+
+// NewLogger creates a clogger.Logger that releases its log files when the app's lifecycle stops. The files stay open
+// until the app's core logger is closed as well, so logs written while stopping are not lost. The logger shares its
+// levels with the core logger so that changing them at runtime applies to both.
+func NewLogger(lc *clifecycle.Lifecycle, config clogger.Config, core clogger.CoreLogger,
+	hooks []clogger.Hook) (clogger.Logger, error) {
+	levels, _ := clogger.LevelsOf(core)
+
+	logger, err := clogger.NewWithLevels(config, levels, hooks)
+	if err != nil {
+		return nil, err
+	}
+
+	if closer, ok := logger.(io.Closer); ok {
+		lc.OnStop(func(ctx context.Context) error {
+			return closer.Close()
+		})
+	}
+
+	return logger, nil
+}
@@ -26,7 +26,7 @@ func InitApp() (*App, error) {
 }
 
 var WireModule = wire.NewSet(
-	wire.FieldsOf(new(*App), "Config", "Lifecycle"),
+	wire.FieldsOf(new(*App), "Config", "Lifecycle", "Logger"),
 	clogger.LoadConfig,
-	clogger.New,
+	NewLogger,
 )
@@ -38,4 +38,4 @@ func InitApp() (*App, error) {
 
 // wire.go:
 
-var WireModule = wire.NewSet(clogger.New, clogger.LoadConfig, wire.FieldsOf(new(*App), "Config", "Lifecycle"))
+var WireModule = wire.NewSet(NewLogger, clogger.LoadConfig, wire.FieldsOf(new(*App), "Config", "Lifecycle", "Logger"))